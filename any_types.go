@@ -0,0 +1,101 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// AllowedAnyTypes and AllowedAnyTypeURLPrefixes are checked by wktAnyDecoder before the type is resolved,
+// so the message of a disallowed type is never created,
+// and they apply at any depth because the same decoder is used for the Any inside the value of another Any.
+// The prefix of a type URL is the part before the last "/", e.g. "type.googleapis.com" of "type.googleapis.com/pkg.v1.Msg",
+// and the prefixes in the options may end with "/" or not.
+
+// anyTypeURLPrefix returns the prefix of typeUrl, empty if there is none
+func anyTypeURLPrefix(typeUrl string) string {
+	if i := strings.LastIndexByte(typeUrl, '/'); i >= 0 {
+		return typeUrl[:i]
+	}
+	return ""
+}
+
+// anyFullName returns the full name of the message of typeUrl, which is the part after the last "/"
+func anyFullName(typeUrl string) protoreflect.FullName {
+	if i := strings.LastIndexByte(typeUrl, '/'); i >= 0 {
+		return protoreflect.FullName(typeUrl[i+1:])
+	}
+	return protoreflect.FullName(typeUrl)
+}
+
+// isAllowedAnyType reports whether typeUrl is allowed by AllowedAnyTypes, all of the types are allowed if it is empty
+func (e *ProtoExtension) isAllowedAnyType(typeUrl string) bool {
+	if len(e.AllowedAnyTypes) <= 0 {
+		return true
+	}
+	name := string(anyFullName(typeUrl))
+	for _, allowed := range e.AllowedAnyTypes {
+		if strings.Contains(allowed, "/") {
+			if typeUrl == allowed {
+				return true
+			}
+			continue
+		}
+		// the prefix matches the whole name segments, e.g. "pkg.v1" matches "pkg.v1.Msg" but not "pkg.v10.Msg"
+		prefix := strings.TrimSuffix(allowed, ".")
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowedAnyTypeURLPrefix reports whether the prefix of typeUrl is allowed by AllowedAnyTypeURLPrefixes,
+// all of the prefixes are allowed if it is empty
+func (e *ProtoExtension) isAllowedAnyTypeURLPrefix(typeUrl string) bool {
+	if len(e.AllowedAnyTypeURLPrefixes) <= 0 {
+		return true
+	}
+	prefix := anyTypeURLPrefix(typeUrl)
+	for _, allowed := range e.AllowedAnyTypeURLPrefixes {
+		if strings.TrimSuffix(allowed, "/") == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// anyResolver returns the resolver of AnyResolvers for the prefix of typeUrl, or the one of the call if there is none, see resolverOf
+func (e *ProtoExtension) anyResolver(attachment interface{}, typeUrl string) interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+} {
+	if len(e.AnyResolvers) > 0 {
+		prefix := anyTypeURLPrefix(typeUrl)
+		if r, ok := e.AnyResolvers[prefix+"/"]; ok {
+			return r
+		}
+		if r, ok := e.AnyResolvers[prefix]; ok {
+			return r
+		}
+	}
+	return e.resolverOf(attachment)
+}
+
+// encodedAnyTypeURL returns typeUrl whose prefix is replaced with AnyTypeURLPrefix if it is set
+func (e *ProtoExtension) encodedAnyTypeURL(typeUrl string) string {
+	if e.AnyTypeURLPrefix == "" {
+		return typeUrl
+	}
+	return strings.TrimSuffix(e.AnyTypeURLPrefix, "/") + "/" + string(anyFullName(typeUrl))
+}
+
+func errDisallowedAnyTypeURLPrefix(typeUrl string) error {
+	return fmt.Errorf("%s: type URL prefix of %q is not allowed", Any_message_fullname, typeUrl)
+}
+
+func errDisallowedAnyType(typeUrl string) error {
+	return fmt.Errorf("%s: type %q is not allowed", Any_message_fullname, typeUrl)
+}
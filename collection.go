@@ -0,0 +1,284 @@
+package jsoniterpb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var errNullElement = errors.New("null is not allowed as the element")
+
+// protojson rejects null in repeated and map fields, except for google.protobuf.Value and google.protobuf.NullValue,
+// but the decoders of slices and maps accept it, so the decoders of the repeated fields and the map fields check the elements,
+// the null elements are reported to FuzzyDecodeCollector with FuzzyDecodeNullToZero instead.
+// protojson also rejects the duplicate keys of a map field, which are compared by the values, e.g. "1" and "01" of map<int32, string>.
+
+func (e *ProtoExtension) createFieldDecoderForElements(fd protoreflect.FieldDescriptor, fieldType reflect2.Type) jsoniter.ValDecoder {
+	if fd == nil {
+		return nil
+	}
+	var checkNull, checkDuplicateKeys bool
+	switch {
+	case fd.IsList():
+		checkNull = !isNullable(fd)
+	case fd.IsMap():
+		checkNull = !isNullable(fd.MapValue())
+		checkDuplicateKeys = e.disallowDuplicateFields()
+	default:
+		return nil
+	}
+	// the keys of the map fields are always parsed by decodeFieldKey
+	if !checkNull && !fd.IsMap() {
+		return nil
+	}
+	return &collectionDecoder{
+		valueType:          fieldType,
+		fd:                 fd,
+		checkNull:          checkNull,
+		allowNull:          e.fuzzyDecode(FuzzyDecodeNullToZero),
+		checkDuplicateKeys: checkDuplicateKeys,
+	}
+}
+
+// collectionDecoder is the same as the decoders of slices, arrays and maps of jsoniter,
+// except that the elements are decoded one by one here:
+// - the index or the key is prepended to the path of the error
+// - the element of an unknown enum name is left out, see takeUnknownEnum
+// - the null elements of the repeated fields and the map fields are checked if checkNull is set
+// - the duplicate keys of the map fields are rejected if checkDuplicateKeys is set
+type collectionDecoder struct {
+	valueType reflect2.Type
+	// fd is set for the repeated fields and the map fields
+	fd protoreflect.FieldDescriptor
+	// checkNull rejects the null elements, or reports them to FuzzyDecodeCollector if allowNull is set
+	checkNull          bool
+	allowNull          bool
+	checkDuplicateKeys bool
+
+	once        sync.Once
+	elemDecoder jsoniter.ValDecoder
+	keyDecoder  jsoniter.ValDecoder
+}
+
+func (dec *collectionDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if st, ok := enterIterCall(iter); ok {
+		defer leaveIterCall(iter, st)
+	}
+	if hasIterError(iter) {
+		return
+	}
+	dec.once.Do(func() {
+		switch typ := dec.valueType.(type) {
+		case reflect2.SliceType:
+			dec.elemDecoder = iter.API().DecoderOf(reflect2.PtrTo(typ.Elem()))
+		case reflect2.ArrayType:
+			dec.elemDecoder = iter.API().DecoderOf(reflect2.PtrTo(typ.Elem()))
+		case reflect2.MapType:
+			dec.elemDecoder = iter.API().DecoderOf(reflect2.PtrTo(typ.Elem()))
+			keyKind := typ.Key().Kind()
+			if dec.fd != nil {
+				// the keys of the map fields are read as strings and parsed by decodeFieldKey
+				keyKind = reflect.String
+			}
+			dec.keyDecoder = iter.API().DecoderOf(reflect2.PtrTo(reflect2.DefaultTypeOfKind(keyKind)))
+		}
+	})
+	switch typ := dec.valueType.(type) {
+	case reflect2.SliceType:
+		dec.decodeSlice(typ, ptr, iter)
+	case reflect2.ArrayType:
+		dec.decodeArray(typ, ptr, iter)
+	case reflect2.MapType:
+		dec.decodeMap(typ, ptr, iter)
+	}
+}
+
+// decodeElem decodes the element at index i or of key, it returns false if failed
+func (dec *collectionDecoder) decodeElem(ptr unsafe.Pointer, iter *jsoniter.Iterator, i int, key *string) bool {
+	if c := fuzzyDecodeCollectorOf(iter); c != nil {
+		if key != nil {
+			c.pushKey(*key)
+		} else {
+			c.pushIndex(i)
+		}
+		defer c.pop()
+		if dec.checkNull && dec.allowNull && iter.WhatIsNext() == jsoniter.NilValue {
+			c.report(FuzzyDecodeNullToZero)
+		}
+	}
+	if dec.checkNull && !dec.allowNull && iter.WhatIsNext() == jsoniter.NilValue {
+		reportIterError(iter, iterOffset(iter), errNullElement)
+	} else {
+		takeUnknownEnum(iter)
+		dec.elemDecoder.Decode(ptr, iter)
+	}
+	if e := iterError(iter); e != nil {
+		if key != nil {
+			e.prependKey(*key)
+		} else {
+			e.prependIndex(i)
+		}
+		setIterError(iter, e)
+		return false
+	}
+	return true
+}
+
+func (dec *collectionDecoder) decodeSlice(typ reflect2.SliceType, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.ReadNil() {
+		typ.UnsafeSetNil(ptr)
+		return
+	}
+	length, index := 0, 0
+	iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+		typ.UnsafeGrow(ptr, length+1)
+		if !dec.decodeElem(typ.UnsafeGetIndex(ptr, length), iter, index, nil) {
+			return false
+		}
+		index++
+		// the element of an unknown enum name is left out, whose place is taken by the next one
+		if !takeUnknownEnum(iter) {
+			length++
+		}
+		return true
+	})
+	if hasIterError(iter) {
+		return
+	}
+	if index == 0 {
+		typ.UnsafeSet(ptr, typ.UnsafeMakeSlice(0, 0))
+		return
+	}
+	typ.UnsafeGrow(ptr, length)
+}
+
+func (dec *collectionDecoder) decodeArray(typ reflect2.ArrayType, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	i := 0
+	iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+		// the elements beyond the length are ignored
+		if i >= typ.Len() {
+			iter.Skip()
+			return true
+		}
+		i++
+		return dec.decodeElem(typ.UnsafeGetIndex(ptr, i-1), iter, i-1, nil)
+	})
+}
+
+func (dec *collectionDecoder) decodeMap(typ reflect2.MapType, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.ReadNil() {
+		typ.UnsafeSet(ptr, typ.UnsafeNew())
+		return
+	}
+	if typ.UnsafeIsNil(ptr) {
+		typ.UnsafeSet(ptr, typ.UnsafeMakeMap(0))
+	}
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		iter.ReadMapCB(nil)
+		return
+	}
+	iter.NextToken()
+	if iter.WhatIsNext() != jsoniter.StringValue {
+		// empty
+		if c := iter.NextToken(); c != '}' {
+			reportSyntaxError(iter, "ReadMapCB", `expect " after {, but found `+string([]byte{c}))
+		}
+		return
+	}
+	var seen map[interface{}]bool
+	for {
+		keyPtr := typ.Key().UnsafeNew()
+		var key string
+		var ok bool
+		if dec.fd != nil {
+			key, ok = dec.decodeFieldKey(typ.Key(), keyPtr, iter)
+		} else {
+			key, ok = dec.decodeKey(typ.Key(), keyPtr, iter)
+		}
+		if !ok {
+			return
+		}
+		if c := iter.NextToken(); c != ':' {
+			reportSyntaxError(iter, "ReadMapCB", "expect : after object field, but found "+string([]byte{c}))
+			return
+		}
+		if dec.checkDuplicateKeys {
+			k := typ.Key().UnsafeIndirect(keyPtr)
+			if seen[k] {
+				reportIterError(iter, iterOffset(iter), fmt.Errorf("duplicate map key %q", key))
+				return
+			}
+			if seen == nil {
+				seen = map[interface{}]bool{}
+			}
+			seen[k] = true
+		}
+		elemPtr := typ.Elem().UnsafeNew()
+		if !dec.decodeElem(elemPtr, iter, 0, &key) {
+			return
+		}
+		// the entry of an unknown enum name is left out
+		if !takeUnknownEnum(iter) {
+			typ.UnsafeSetIndex(ptr, keyPtr, elemPtr)
+		}
+
+		switch c := iter.NextToken(); c {
+		case ',':
+		case '}':
+			return
+		default:
+			reportSyntaxError(iter, "ReadMapCB", `expect }, but found `+string([]byte{c}))
+			return
+		}
+	}
+}
+
+// decodeFieldKey reads the key of a map field, which is parsed in the same way as protojson, e.g. "01" is 1 of map<int32, string>
+func (dec *collectionDecoder) decodeFieldKey(keyType reflect2.Type, ptr unsafe.Pointer, iter *jsoniter.Iterator) (string, bool) {
+	offset := iterOffset(iter)
+	var key string
+	dec.keyDecoder.Decode(unsafe.Pointer(&key), iter)
+	if hasIterError(iter) {
+		return "", false
+	}
+	k, err := parseMapKey(dec.fd.MapKey(), key)
+	if err != nil {
+		reportIterError(iter, offset, err)
+		return "", false
+	}
+	keyType.UnsafeSet(ptr, reflect2.PtrOf(k.Interface()))
+	return key, true
+}
+
+// decodeKey is the same as the map key decoder of jsoniter, the keys of other kinds are in quotes, e.g. "1".
+// It returns the key as it is in the input for the path and the errors, which is formatted from the decoded one
+// if the input is unknown, e.g. the iterator reads from an io.Reader.
+func (dec *collectionDecoder) decodeKey(keyType reflect2.Type, ptr unsafe.Pointer, iter *jsoniter.Iterator) (string, bool) {
+	if keyType.Kind() == reflect.String {
+		dec.keyDecoder.Decode(ptr, iter)
+		return *((*string)(ptr)), !hasIterError(iter)
+	}
+	if c := iter.NextToken(); c != '"' {
+		reportSyntaxError(iter, "ReadMapCB", `expect ", but found `+string([]byte{c}))
+		return "", false
+	}
+	begin := iterOffset(iter)
+	dec.keyDecoder.Decode(ptr, iter)
+	if hasIterError(iter) {
+		return "", false
+	}
+	if c := iter.NextToken(); c != '"' {
+		reportSyntaxError(iter, "ReadMapCB", `expect ", but found `+string([]byte{c}))
+		return "", false
+	}
+	if input, end := iterInput(iter), iterOffset(iter)-1; begin >= 0 && input != nil && end >= begin {
+		return string(input[begin:end]), true
+	}
+	return mapKeyString(keyType, ptr), true
+}
@@ -0,0 +1,258 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protojson rejects a field which appears more than once in an object,
+// including the case that both of the json name and the proto name of one field are present,
+// e.g. {"fooBar":1,"foo_bar":2}
+// It also rejects a oneof which is set by more than one member, e.g. {"oneofString":"a","oneofEnum":"ZERO"},
+// but the member set to null is ignored because null means unset.
+
+// DuplicateFieldsPolicy specifies how to handle the duplicate fields of a message when unmarshaling
+type DuplicateFieldsPolicy int
+
+const (
+	// DuplicateFieldsDefault accepts duplicate fields and the last one wins, unless Strict is set
+	DuplicateFieldsDefault DuplicateFieldsPolicy = iota
+	// DuplicateFieldsDisallow rejects duplicate fields, which is the same as protojson
+	DuplicateFieldsDisallow
+)
+
+func (e *ProtoExtension) disallowDuplicateFields() bool {
+	return e.Strict || e.DuplicateFields == DuplicateFieldsDisallow
+}
+
+// findFieldByName finds the field of md by the custom name, the json name, the proto name or the aliases,
+// and the name is case insensitive if not caseSensitive, which is the same as the struct decoder of jsoniter.
+// The ignored fields are never found, so they are unknown fields.
+func (e *ProtoExtension) findFieldByName(md protoreflect.MessageDescriptor, name string, caseSensitive bool) protoreflect.FieldDescriptor {
+	if fd := e.findField(md, name, caseSensitive); fd != nil && !e.isIgnoredField(fd) {
+		return fd
+	}
+	return nil
+}
+
+func (e *ProtoExtension) findField(md protoreflect.MessageDescriptor, name string, caseSensitive bool) protoreflect.FieldDescriptor {
+	fields := e.fieldsByNameOf(md)
+	if fd, ok := fields.exact[name]; ok {
+		return fd
+	}
+	if caseSensitive {
+		return nil
+	}
+	return fields.folded[strings.ToLower(name)]
+}
+
+// fieldsByName are the fields of a message by the names accepted for the input,
+// exact is in the order of the custom names, the json names, the proto names and the aliases,
+// and folded is by the lower case names of each field in turn.
+// err is set if an alias conflicts with a name of another field, then the message is never decoded.
+type fieldsByName struct {
+	exact  map[string]protoreflect.FieldDescriptor
+	folded map[string]protoreflect.FieldDescriptor
+	err    error
+}
+
+// fieldsByNameOf returns the fields of md by the names, they are cached per message in e, so that the descriptors are released with the config
+func (e *ProtoExtension) fieldsByNameOf(md protoreflect.MessageDescriptor) *fieldsByName {
+	if v, ok := e.fieldsByName.Load(md); ok {
+		return v.(*fieldsByName)
+	}
+
+	fields := &fieldsByName{
+		exact:  map[string]protoreflect.FieldDescriptor{},
+		folded: map[string]protoreflect.FieldDescriptor{},
+	}
+	add := func(m map[string]protoreflect.FieldDescriptor, name string, fd protoreflect.FieldDescriptor) {
+		if _, ok := m[name]; !ok && name != "" {
+			m[name] = fd
+		}
+	}
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		add(fields.exact, e.customFieldName(fds.Get(i)), fds.Get(i))
+	}
+	for i := 0; i < fds.Len(); i++ {
+		add(fields.exact, fds.Get(i).JSONName(), fds.Get(i))
+	}
+	for i := 0; i < fds.Len(); i++ {
+		add(fields.exact, fds.Get(i).TextName(), fds.Get(i))
+	}
+	for i := 0; i < fds.Len(); i++ {
+		for _, alias := range e.fieldAliases(fds.Get(i)) {
+			add(fields.exact, alias, fds.Get(i))
+		}
+	}
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		for _, name := range append([]string{fd.JSONName(), fd.TextName(), e.customFieldName(fd)}, e.fieldAliases(fd)...) {
+			add(fields.folded, strings.ToLower(name), fd)
+		}
+	}
+	if e.hasFieldAliases() {
+		fields.err = e.checkFieldAliases(md)
+	}
+	v, _ := e.fieldsByName.LoadOrStore(md, fields)
+	return v.(*fieldsByName)
+}
+
+// duplicateFieldChecker remembers the fields and the oneofs have been seen in an object
+type duplicateFieldChecker struct {
+	ext                     *ProtoExtension
+	md                      protoreflect.MessageDescriptor
+	disallowDuplicateFields bool
+
+	seenNums   map[protoreflect.FieldNumber]bool
+	seenOneofs map[protoreflect.Name]bool
+}
+
+// check returns an error if fd or its oneof has been seen, isNull tells whether the value is null
+func (c *duplicateFieldChecker) check(fd protoreflect.FieldDescriptor, isNull bool) error {
+	if c.disallowDuplicateFields {
+		if c.seenNums[fd.Number()] {
+			return fmt.Errorf("duplicate field %q", c.ext.fieldName(fd))
+		}
+		if c.seenNums == nil {
+			c.seenNums = map[protoreflect.FieldNumber]bool{}
+		}
+		c.seenNums[fd.Number()] = true
+	}
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && (!isNull || isNullable(fd)) {
+		if c.seenOneofs[od.Name()] {
+			return fmt.Errorf("error parsing %q, oneof %v is already set", c.ext.fieldName(fd), od.FullName())
+		}
+		if c.seenOneofs == nil {
+			c.seenOneofs = map[protoreflect.Name]bool{}
+		}
+		c.seenOneofs[od.Name()] = true
+	}
+	return nil
+}
+
+// reset makes the checker for a new object of md, the maps are kept to be reused
+func (c *duplicateFieldChecker) reset(md protoreflect.MessageDescriptor) {
+	c.md = md
+	for k := range c.seenNums {
+		delete(c.seenNums, k)
+	}
+	for k := range c.seenOneofs {
+		delete(c.seenOneofs, k)
+	}
+}
+
+// hasRealOneofs reports whether md has a oneof which is not generated for optional
+func hasRealOneofs(md protoreflect.MessageDescriptor) bool {
+	ods := md.Oneofs()
+	for i := 0; i < ods.Len(); i++ {
+		if !ods.Get(i).IsSynthetic() {
+			return true
+		}
+	}
+	return false
+}
+
+// The struct decoder of jsoniter decodes the fields by the bindings,
+// so protoDuplicateFieldsDecoder starts a checker for each object in the state of the call,
+// and protoDuplicateFieldDecoder of each binding checks its field against the checker of the innermost object,
+// the members of oneof are checked by protoOneofWrapperDecoder.
+
+func (e *ProtoExtension) decorateDecoderForDuplicateFields(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	md := protoMessageDescriptor(typ)
+	// the messages decoded via protoreflect are checked by protoReflectMessageDecoder
+	if md == nil || md.ExtensionRanges().Len() > 0 {
+		return nil
+	}
+	if !e.disallowDuplicateFields() && !hasRealOneofs(md) {
+		return nil
+	}
+	return &protoDuplicateFieldsDecoder{
+		ext:          e,
+		md:           md,
+		valueDecoder: decoder,
+	}
+}
+
+type protoDuplicateFieldsDecoder struct {
+	ext          *ProtoExtension
+	md           protoreflect.MessageDescriptor
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *protoDuplicateFieldsDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.WhatIsNext() != jsoniter.ObjectValue {
+		dec.valueDecoder.Decode(ptr, iter)
+		return
+	}
+	st, ok := enterIterCall(iter)
+	if ok {
+		defer leaveIterCall(iter, st)
+	}
+
+	// the checkers of the objects which have been done are reused
+	n := len(st.objects)
+	if n < cap(st.objects) && st.objects[:n+1][n] != nil {
+		st.objects = st.objects[:n+1]
+		st.objects[n].reset(dec.md)
+	} else {
+		st.objects = append(st.objects, &duplicateFieldChecker{
+			ext:                     dec.ext,
+			md:                      dec.md,
+			disallowDuplicateFields: dec.ext.disallowDuplicateFields(),
+		})
+	}
+	defer func() {
+		st.objects = st.objects[:n]
+	}()
+	dec.valueDecoder.Decode(ptr, iter)
+}
+
+func (e *ProtoExtension) wrapFieldDecoderForDuplicateFields(fd protoreflect.FieldDescriptor, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if fd == nil || !e.disallowDuplicateFields() {
+		return nil
+	}
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return nil
+	}
+	return &protoDuplicateFieldDecoder{
+		fd:           fd,
+		valueDecoder: decoder,
+	}
+}
+
+type protoDuplicateFieldDecoder struct {
+	fd           protoreflect.FieldDescriptor
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *protoDuplicateFieldDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if !checkDuplicateField(iter, dec.fd) {
+		return
+	}
+	dec.valueDecoder.Decode(ptr, iter)
+}
+
+// checkDuplicateField checks fd against the checker of the innermost object before its value is decoded,
+// it returns false if fd or its oneof has been seen
+func checkDuplicateField(iter *jsoniter.Iterator, fd protoreflect.FieldDescriptor) bool {
+	st, ok := iter.Attachment.(*callState)
+	if !ok || len(st.objects) <= 0 {
+		return true
+	}
+	c := st.objects[len(st.objects)-1]
+	if c.md != fd.ContainingMessage() {
+		return true
+	}
+	if err := c.check(fd, iter.WhatIsNext() == jsoniter.NilValue); err != nil {
+		reportIterError(iter, iterOffset(iter), err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package jsoniterpb
+
+import (
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EmitPolicy specifies how to marshal an unpopulated field, which is returned by ProtoExtension.EmitPolicy
+type EmitPolicy int
+
+const (
+	// EmitPolicyDefault follows EmitUnpopulated and EmitDefaultValues
+	EmitPolicyDefault EmitPolicy = iota
+	// EmitPolicyAlways emits the field, which is the zero value, or null if the field has presence, the same as EmitUnpopulated
+	EmitPolicyAlways
+	// EmitPolicyOmitEmpty omits the field, even if EmitUnpopulated or EmitDefaultValues is set
+	EmitPolicyOmitEmpty
+	// EmitPolicyNull emits the field as null, including the scalar fields without presence, e.g. "count": null
+	EmitPolicyNull
+)
+
+// emitPolicy returns the policy of fd of the message, EmitPolicyDefault for the members of a oneof,
+// which are never emitted unless set, the same as protojson.
+// The omit_empty option of fd takes precedence over EmitPolicy.
+func (e *ProtoExtension) emitPolicy(fd protoreflect.FieldDescriptor, message protoreflect.FullName) EmitPolicy {
+	if fd == nil {
+		return EmitPolicyDefault
+	}
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		return EmitPolicyDefault
+	}
+	if fo := e.fieldOptionsOf(fd); fo != nil && fo.OmitEmpty != nil {
+		if fo.GetOmitEmpty() {
+			return EmitPolicyOmitEmpty
+		}
+		return EmitPolicyAlways
+	}
+	if e.EmitPolicy == nil {
+		return EmitPolicyDefault
+	}
+	return e.EmitPolicy(fd, message)
+}
+
+// protoEmitPolicyEncoder is the encoder of a field whose policy is EmitPolicyAlways or EmitPolicyNull,
+// the unset field with presence is emitted as null with EmitPolicyAlways, e.g. the bytes field which is not a pointer
+type protoEmitPolicyEncoder struct {
+	emitNull bool
+	jsoniter.ValEncoder
+}
+
+func newProtoEmitPolicyEncoder(policy EmitPolicy, fd protoreflect.FieldDescriptor, encoder jsoniter.ValEncoder) *protoEmitPolicyEncoder {
+	return &protoEmitPolicyEncoder{
+		emitNull:   policy == EmitPolicyNull || fd.HasPresence(),
+		ValEncoder: encoder,
+	}
+}
+
+func (enc *protoEmitPolicyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if enc.emitNull && enc.ValEncoder.IsEmpty(ptr) {
+		stream.WriteNil()
+		return
+	}
+	enc.ValEncoder.Encode(ptr, stream)
+}
+
+func (enc *protoEmitPolicyEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return false
+}
+
+func (enc *protoEmitPolicyEncoder) IsEmbeddedPtrNil(ptr unsafe.Pointer) bool {
+	isEmbeddedPtrNil, converted := enc.ValEncoder.(jsoniter.IsEmbeddedPtrNil)
+	if !converted {
+		return false
+	}
+	return isEmbeddedPtrNil.IsEmbeddedPtrNil(ptr)
+}
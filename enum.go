@@ -23,8 +23,9 @@ var (
 )
 
 func (e *ProtoExtension) createProtoEnumEncoder(typ reflect2.Type) (xret jsoniter.ValEncoder) {
-	if !e.UseEnumNumbers {
-		if typ.Implements(protoEnumType) && typ.Kind() != reflect.Ptr {
+	if typ.Implements(protoEnumType) && typ.Kind() != reflect.Ptr {
+		// NullValue is always marshaled to null, even if UseEnumNumbers
+		if !e.UseEnumNumbers || typ == nullValuePtrType.(reflect2.PtrType).Elem() {
 			return &protoEnumEncoder{
 				valueType: typ,
 			}
@@ -38,19 +39,25 @@ func (e *ProtoExtension) createProtoEnumDecoder(typ reflect2.Type) (xret jsonite
 	if typ.Implements(protoEnumType) {
 		if typ.Kind() != reflect.Ptr {
 			return &protoEnumDecoder{
+				ext:       e,
 				valueType: typ,
 			}
 		}
 
+		// - unmarshal null to NULL_VALUE instead of nil pointer
+		// - the name or number of the enum value is also valid, e.g. optional NullValue of proto2
 		if typ == nullValuePtrType {
+			elemDecoder := &protoEnumDecoder{
+				ext:       e,
+				valueType: typ.(reflect2.PtrType).Elem(),
+			}
 			return &funcDecoder{
 				fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
-					if iter.ReadNil() {
-						v := structpb.NullValue_NULL_VALUE
-						*((**structpb.NullValue)(ptr)) = &v
-					} else {
-						iter.ReportError("protobuf", fmt.Sprintf("%v: invalid value %v", NullValue_enum_fullname, string(iter.SkipAndReturnBytes())))
+					v := structpb.NullValue_NULL_VALUE
+					if !iter.ReadNil() {
+						elemDecoder.Decode(unsafe.Pointer(&v), iter)
 					}
+					*((**structpb.NullValue)(ptr)) = &v
 				},
 			}
 		}
@@ -91,6 +98,7 @@ func (enc *protoEnumEncoder) IsEmpty(ptr unsafe.Pointer) bool {
 }
 
 type protoEnumDecoder struct {
+	ext          *ProtoExtension
 	valueType    reflect2.Type
 	once         sync.Once
 	enumValDescs protoreflect.EnumValueDescriptors
@@ -98,6 +106,7 @@ type protoEnumDecoder struct {
 
 func (dec *protoEnumDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 	valueType := iter.WhatIsNext()
+	start := iterOffset(iter)
 	switch valueType {
 	case jsoniter.NumberValue:
 		num := iter.ReadInt32()
@@ -115,10 +124,14 @@ func (dec *protoEnumDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator)
 		} else {
 			// is "num"?
 			num, err := strconv.ParseInt(name, 10, 32)
-			if err == nil {
+			if err == nil && dec.ext.fuzzyDecode(FuzzyDecodeEnumNumericString) {
 				*((*protoreflect.EnumNumber)(ptr)) = protoreflect.EnumNumber(num)
+				reportFuzzyDecode(iter, FuzzyDecodeEnumNumericString)
+			} else if discardUnknown(iter) {
+				// the unknown name is ignored like an unknown field, which is the same as protojson
+				markUnknownEnum(iter)
 			} else {
-				iter.ReportError("protobuf", fmt.Sprintf(
+				reportIterError(iter, start, fmt.Errorf(
 					"error decode from string for type %s",
 					dec.valueType,
 				))
@@ -128,7 +141,7 @@ func (dec *protoEnumDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator)
 		iter.Skip()
 		*((*protoreflect.EnumNumber)(ptr)) = 0
 	default:
-		iter.ReportError("protobuf", fmt.Sprintf(
+		reportIterError(iter, start, fmt.Errorf(
 			"error decode for type %s",
 			dec.valueType,
 		))
@@ -0,0 +1,403 @@
+package jsoniterpb
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"github.com/molon/jsoniterpb/extra"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The path of Error is built from the inside out:
+// - struct fields prepend their names, and the proto full names for message fields
+// - lists and maps prepend the index or the key of the failed element
+// - structs, lists and maps restore the typed error flattened by jsoniter,
+//   and the outermost of them sets the state of the call, see callState
+// Only the proto messages and the types containing them are decorated, the others are left to jsoniter as they are,
+// so the encoders of the repeated fields and the map fields of scalars, which are shared with the plain Go types,
+// locate the failed element only after the error, see locateFailedElement.
+
+// containsProtoMessage reports whether typ is a proto message or a proto enum, or it contains any of them,
+// e.g. a struct with a field of *pb.Msg or map[string][]*pb.Msg
+func containsProtoMessage(typ reflect2.Type) bool {
+	return containsType(typ.Type1(), isProtoType, map[reflect.Type]bool{})
+}
+
+var (
+	protoMessageRType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	protoEnumRType    = reflect.TypeOf((*protoreflect.Enum)(nil)).Elem()
+)
+
+func isProtoType(typ reflect.Type) bool {
+	return typ.Implements(protoMessageRType) || reflect.PtrTo(typ).Implements(protoMessageRType) || typ.Implements(protoEnumRType)
+}
+
+// containsType reports whether typ or any type inside it matches
+func containsType(typ reflect.Type, match func(reflect.Type) bool, visited map[reflect.Type]bool) bool {
+	if match(typ) {
+		return true
+	}
+	if visited[typ] {
+		return false
+	}
+	visited[typ] = true
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsType(typ.Elem(), match, visited)
+	case reflect.Map:
+		return containsType(typ.Key(), match, visited) || containsType(typ.Elem(), match, visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if containsType(typ.Field(i).Type, match, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *ProtoExtension) updateStructDescriptorForErrorPath(desc *jsoniter.StructDescriptor) {
+	if !containsProtoMessage(desc.Type) {
+		return
+	}
+	md := protoMessageDescriptor(desc.Type)
+	for _, binding := range desc.Fields {
+		if len(binding.FromNames) <= 0 && len(binding.ToNames) <= 0 {
+			continue
+		}
+		tag, hastag := binding.Field.Tag().Lookup("protobuf")
+		// the fields of oneof wrappers are handled by protoOneofWrapperEncoder and protoOneofWrapperDecoder
+		if md == nil && hastag && strings.Contains(","+tag+",", ",oneof,") {
+			continue
+		}
+
+		name := binding.Field.Name()
+		if len(binding.ToNames) > 0 {
+			name = binding.ToNames[0]
+		}
+		fd := fieldDescriptorOfBinding(md, binding)
+
+		if binding.Decoder != nil {
+			binding.Decoder = &errorPathFieldDecoder{name, fd, binding.Decoder}
+		}
+		if binding.Encoder != nil {
+			var collectionType reflect2.Type
+			if fd != nil && (fd.IsList() || fd.IsMap()) && !containsProtoMessage(binding.Field.Type()) {
+				collectionType = binding.Field.Type()
+			}
+			binding.Encoder = wrapFieldEncoderForErrorPath(binding.Encoder, name, fd, collectionType)
+		}
+	}
+}
+
+// wrapFieldEncoderForErrorPath keeps the encoders of extra outermost,
+// since extra.EmitEmptyEncoder checks the type of extra.ImmunityEmitEmptyEncoder
+func wrapFieldEncoderForErrorPath(encoder jsoniter.ValEncoder, name string, fd protoreflect.FieldDescriptor, collectionType reflect2.Type) jsoniter.ValEncoder {
+	switch enc := encoder.(type) {
+	case *extra.EmitEmptyEncoder:
+		enc.ValEncoder = wrapFieldEncoderForErrorPath(enc.ValEncoder, name, fd, collectionType)
+		return enc
+	case *extra.ImmunityEmitEmptyEncoder:
+		enc.ValEncoder = wrapFieldEncoderForErrorPath(enc.ValEncoder, name, fd, collectionType)
+		return enc
+	}
+	return &errorPathFieldEncoder{name, fd, collectionType, encoder}
+}
+
+type errorPathFieldDecoder struct {
+	name         string
+	fd           protoreflect.FieldDescriptor
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *errorPathFieldDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if c := fuzzyDecodeCollectorOf(iter); c != nil {
+		c.pushField(dec.name, dec.fd)
+		defer c.pop()
+	}
+	if hasIterError(iter) {
+		return
+	}
+	dec.valueDecoder.Decode(ptr, iter)
+	if e := iterError(iter); e != nil {
+		e.prependField(dec.name, dec.fd)
+		setIterError(iter, e)
+	}
+}
+
+type errorPathFieldEncoder struct {
+	name string
+	fd   protoreflect.FieldDescriptor
+	// collectionType is set for the repeated fields and the map fields whose elements are not decorated, see locateFailedElement
+	collectionType reflect2.Type
+	valueEncoder   jsoniter.ValEncoder
+}
+
+func (enc *errorPathFieldEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	// jsoniter keeps encoding the rest after an error, whose output is dropped anyway
+	if hasStreamError(stream) {
+		return
+	}
+	enc.valueEncoder.Encode(ptr, stream)
+	if e := streamError(stream); e != nil {
+		if enc.collectionType != nil {
+			locateFailedElement(enc.collectionType, ptr, stream, e)
+		}
+		e.prependField(enc.name, enc.fd)
+		setStreamError(stream, e)
+	}
+}
+
+// locateFailedElement prepends the index or the key of the first element of the list or the map at ptr which fails to encode,
+// the elements are encoded again one by one, which only happens after an error
+func locateFailedElement(typ reflect2.Type, ptr unsafe.Pointer, stream *jsoniter.Stream, e *Error) {
+	var resolver interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+	if st, ok := stream.Attachment.(*callState); ok {
+		resolver = st.resolver
+	}
+	api := stream.API()
+	failed := func(elemType reflect2.Type, elem unsafe.Pointer) bool {
+		subStream := api.BorrowStream(nil)
+		defer api.ReturnStream(subStream)
+		subStream.Attachment = &callState{resolver: resolver}
+		api.EncoderOf(elemType).Encode(elem, subStream)
+		return hasStreamError(subStream)
+	}
+	switch typ := typ.(type) {
+	case reflect2.SliceType:
+		for i, n := 0, typ.UnsafeLengthOf(ptr); i < n; i++ {
+			if failed(typ.Elem(), typ.UnsafeGetIndex(ptr, i)) {
+				e.prependIndex(i)
+				return
+			}
+		}
+	case reflect2.MapType:
+		for iter := reflect.NewAt(typ.Type1(), ptr).Elem().MapRange(); iter.Next(); {
+			elem := iter.Value().Interface()
+			if failed(typ.Elem(), reflect2.PtrOf(elem)) {
+				e.prependKey(fmt.Sprint(iter.Key().Interface()))
+				return
+			}
+		}
+	}
+}
+
+func (enc *errorPathFieldEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
+
+func (enc *errorPathFieldEncoder) IsEmbeddedPtrNil(ptr unsafe.Pointer) bool {
+	isEmbeddedPtrNil, converted := enc.valueEncoder.(jsoniter.IsEmbeddedPtrNil)
+	if !converted {
+		return false
+	}
+	return isEmbeddedPtrNil.IsEmbeddedPtrNil(ptr)
+}
+
+func isErrorPathCollection(typ reflect2.Type) bool {
+	switch typ.Kind() {
+	case reflect.Slice:
+		return typ.(reflect2.SliceType).Elem().Kind() != reflect.Uint8
+	case reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+var (
+	unmarshalerType     = reflect2.TypeOfPtr((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect2.TypeOfPtr((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isUnmarshaler reports whether typ is decoded by its own methods instead of the decoders of jsoniter
+func isUnmarshaler(typ reflect2.Type) bool {
+	ptrType := reflect2.PtrTo(typ)
+	return typ.Implements(unmarshalerType) || ptrType.Implements(unmarshalerType) ||
+		typ.Implements(textUnmarshalerType) || ptrType.Implements(textUnmarshalerType)
+}
+
+// isPlainMapKey reports whether the keys of typ are decoded by the decoder of its kind
+func isPlainMapKey(typ reflect2.Type) bool {
+	if isUnmarshaler(typ) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (e *ProtoExtension) decorateDecoderForErrorPath(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if !containsProtoMessage(typ) {
+		return nil
+	}
+	if typ.Kind() == reflect.Struct {
+		return &errorPathDecoder{
+			valueDecoder: decoder,
+		}
+	}
+	// the lists and the maps are decoded element by element by collectionDecoder, so that the index or the key of the failed one is known
+	if !isErrorPathCollection(typ) || isUnmarshaler(typ) {
+		return nil
+	}
+	if mapType, ok := typ.(reflect2.MapType); ok && !isPlainMapKey(mapType.Key()) {
+		return nil
+	}
+	return &collectionDecoder{
+		valueType: typ,
+	}
+}
+
+type errorPathDecoder struct {
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *errorPathDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if st, ok := enterIterCall(iter); ok {
+		defer leaveIterCall(iter, st)
+	}
+	if hasIterError(iter) {
+		return
+	}
+	dec.valueDecoder.Decode(ptr, iter)
+	if e := iterError(iter); e != nil {
+		setIterError(iter, e)
+	}
+}
+
+// mapKeyString returns the key in the path of errors
+func mapKeyString(keyType reflect2.Type, ptr unsafe.Pointer) string {
+	if keyType.Kind() == reflect.String {
+		return *(*string)(ptr)
+	}
+	return fmt.Sprint(keyType.UnsafeIndirect(ptr))
+}
+
+func (e *ProtoExtension) decorateEncoderForErrorPath(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if typ.Kind() != reflect.Struct && !isErrorPathCollection(typ) || !containsProtoMessage(typ) {
+		return nil
+	}
+	return &errorPathEncoder{
+		isCollection: typ.Kind() != reflect.Struct,
+		valueEncoder: encoder,
+	}
+}
+
+type errorPathEncoder struct {
+	isCollection bool
+	valueEncoder jsoniter.ValEncoder
+}
+
+func (enc *errorPathEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	st, ok := enterStreamCall(stream)
+	if ok {
+		defer leaveStreamCall(stream, st)
+	}
+	if hasStreamError(stream) {
+		return
+	}
+	if enc.isCollection {
+		// counts the elements for errorPathElemEncoder
+		st.collections = append(st.collections, collectionFrame{})
+		defer func() {
+			st.collections = st.collections[:len(st.collections)-1]
+		}()
+	}
+	enc.valueEncoder.Encode(ptr, stream)
+	if e := streamError(stream); e != nil {
+		setStreamError(stream, e)
+	}
+}
+
+func (enc *errorPathEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
+
+// collectionFrame is the list or the map being encoded
+type collectionFrame struct {
+	index int
+	key   unsafe.Pointer
+}
+
+func (e *ProtoExtension) updateMapEncoderConstructorForErrorPath(v *jsoniter.MapEncoderConstructor) {
+	if !containsProtoMessage(v.MapType) {
+		return
+	}
+	v.KeyEncoder = &errorPathKeyEncoder{v.KeyEncoder}
+	v.ElemEncoder = &errorPathElemEncoder{keyType: v.MapType.Key(), valueEncoder: v.ElemEncoder}
+}
+
+func (e *ProtoExtension) updateSliceEncoderConstructorForErrorPath(v *jsoniter.SliceEncoderConstructor) {
+	if !containsProtoMessage(v.SliceType) {
+		return
+	}
+	v.ElemEncoder = &errorPathElemEncoder{valueEncoder: v.ElemEncoder}
+}
+
+func (e *ProtoExtension) updateArrayEncoderConstructorForErrorPath(v *jsoniter.ArrayEncoderConstructor) {
+	if !containsProtoMessage(v.ArrayType) {
+		return
+	}
+	v.ElemEncoder = &errorPathElemEncoder{valueEncoder: v.ElemEncoder}
+}
+
+// errorPathKeyEncoder remembers the key of the map entry being encoded, which is followed by the element
+type errorPathKeyEncoder struct {
+	jsoniter.ValEncoder
+}
+
+func (enc *errorPathKeyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if st, ok := stream.Attachment.(*callState); ok && len(st.collections) > 0 {
+		st.collections[len(st.collections)-1].key = ptr
+	}
+	enc.ValEncoder.Encode(ptr, stream)
+}
+
+// errorPathElemEncoder prepends the index or the key of the failed element, keyType is nil for lists
+type errorPathElemEncoder struct {
+	keyType      reflect2.Type
+	valueEncoder jsoniter.ValEncoder
+}
+
+func (enc *errorPathElemEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	// jsoniter keeps encoding the rest after an error, whose output is dropped anyway
+	if hasStreamError(stream) {
+		return
+	}
+	st, ok := stream.Attachment.(*callState)
+	if !ok || len(st.collections) <= 0 {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	f := &st.collections[len(st.collections)-1]
+	index, key := f.index, f.key
+	f.index++
+	enc.valueEncoder.Encode(ptr, stream)
+	if e := streamError(stream); e != nil {
+		if enc.keyType != nil && key != nil {
+			e.prependKey(mapKeyString(enc.keyType, key))
+		} else if enc.keyType == nil {
+			e.prependIndex(index)
+		}
+		setStreamError(stream, e)
+	}
+}
+
+func (enc *errorPathElemEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
@@ -0,0 +1,313 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Error is the error of marshaling and unmarshaling, which tells where the failure is, e.g.
+//
+//	var perr *jsoniterpb.Error
+//	if errors.As(err, &perr) {
+//		fmt.Println(perr.Path, perr.FieldName, perr.Offset)
+//	}
+type Error struct {
+	// Path is the JSON path of the failed value, e.g. `a.b[3].c` or `m["k"]`, empty for the top-level value
+	Path string
+	// FieldName is the full name of the innermost proto field on the path, empty if there is none
+	FieldName protoreflect.FullName
+	// Offset is the byte offset of the failure in the input when unmarshaling, -1 if unknown
+	Offset int
+	// Line and Column are the 1-based position of Offset, they are set by UnmarshalOptions only
+	Line   int
+	Column int
+	// Err is the underlying cause, it is the error of protojson with ProtojsonErrors
+	Err error
+
+	protojson bool
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" || e.protojson {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// prependField adds the field name to the front of the path, fd could be nil for a non-proto struct field
+func (e *Error) prependField(name string, fd protoreflect.FieldDescriptor) {
+	if e.FieldName == "" && fd != nil {
+		e.FieldName = fd.FullName()
+	}
+	if e.Path == "" {
+		e.Path = name
+		return
+	}
+	if isIndexPathSegment(e.Path) {
+		e.Path = name + e.Path
+		return
+	}
+	e.Path = name + "." + e.Path
+}
+
+// prependIndex adds the index of a list element to the front of the path
+func (e *Error) prependIndex(i int) {
+	e.prependIndexSegment("[" + strconv.Itoa(i) + "]")
+}
+
+// prependKey adds the key of a map entry to the front of the path
+func (e *Error) prependKey(key string) {
+	e.prependIndexSegment("[" + strconv.Quote(key) + "]")
+}
+
+func (e *Error) prependIndexSegment(seg string) {
+	if e.Path == "" || isIndexPathSegment(e.Path) {
+		e.Path = seg + e.Path
+		return
+	}
+	e.Path = seg + "." + e.Path
+}
+
+// isIndexPathSegment reports whether the path starts with an index or a key,
+// which is different from an extension field name, e.g. `[3]`, `["k"]` and `[pkg.ext]`
+func isIndexPathSegment(path string) bool {
+	return len(path) > 1 && path[0] == '[' && (path[1] == '"' || (path[1] >= '0' && path[1] <= '9'))
+}
+
+// locate sets Line and Column from Offset of the given input
+func (e *Error) locate(data []byte) {
+	if e.Offset < 0 || e.Offset > len(data) {
+		return
+	}
+	before := data[:e.Offset]
+	e.Line = 1 + strings.Count(string(before), "\n")
+	e.Column = 1 + e.Offset - (strings.LastIndexByte(string(before), '\n') + 1)
+}
+
+// jsoniter flattens the errors of struct fields, structs, slices and arrays into strings, e.g. "fieldName: cause",
+// so the typed error is also kept in the state of the call, and it is restored after each flattening.
+
+// callState is the state of a call of marshaling or unmarshaling, which is carried by Attachment of the iterator or the stream,
+// so it is shared with the sub iterators and the sub streams.
+// The attachment of the caller, e.g. FuzzyDecodeCollector, is kept in it and put back when the call returns.
+type callState struct {
+	attachment interface{}
+	// err is the last typed error set in the call
+	err *Error
+	// collections are the lists and the maps being encoded, from the outside in
+	collections []collectionFrame
+	// unknownEnum is set if an unknown enum name is ignored, see takeUnknownEnum
+	unknownEnum bool
+	// objects are the checkers of the messages being decoded, from the outside in, see protoDuplicateFieldsDecoder
+	objects []*duplicateFieldChecker
+	// indenting is set if the outermost message or map is being indented, see protoIndentionEncoder
+	indenting bool
+	// depth is the number of the objects and the arrays containing the outermost message or map, see indentionDepthEncoder
+	depth int
+	// input is the input of the call, the sub iterators read the other buffers, see protojsonErrorsDecoder
+	input []byte
+	// resolver is given by MarshalOptions or UnmarshalOptions, see resolverOf
+	resolver interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+}
+
+// enterIterCall sets a call state to iter if there is none, leaveIterCall must be called if it returns true
+func enterIterCall(iter *jsoniter.Iterator) (*callState, bool) {
+	if st, ok := iter.Attachment.(*callState); ok {
+		return st, false
+	}
+	st := &callState{attachment: iter.Attachment, input: iterInput(iter)}
+	iter.Attachment = st
+	return st, true
+}
+
+// leaveIterCall puts back the attachment of the caller and leaves the typed error in iter.Error
+func leaveIterCall(iter *jsoniter.Iterator, st *callState) {
+	if e := iterError(iter); e != nil {
+		iter.Error = e
+	}
+	iter.Attachment = st.attachment
+}
+
+func enterStreamCall(stream *jsoniter.Stream) (*callState, bool) {
+	if st, ok := stream.Attachment.(*callState); ok {
+		return st, false
+	}
+	st := &callState{attachment: stream.Attachment}
+	stream.Attachment = st
+	return st, true
+}
+
+func leaveStreamCall(stream *jsoniter.Stream, st *callState) {
+	if e := streamError(stream); e != nil {
+		stream.Error = e
+	}
+	stream.Attachment = st.attachment
+}
+
+// typedError returns the typed error behind err, which is wrapped as the cause if there is none
+func typedError(attachment interface{}, err error, offset int) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	if st, ok := attachment.(*callState); ok && st.err != nil {
+		return st.err
+	}
+	return &Error{Offset: offset, Err: err}
+}
+
+func setIterError(iter *jsoniter.Iterator, e *Error) {
+	iter.Error = e
+	if st, ok := iter.Attachment.(*callState); ok {
+		st.err = e
+	}
+}
+
+// clearIterError drops the error of iter, e.g. before decoding the input again
+func clearIterError(iter *jsoniter.Iterator) {
+	iter.Error = nil
+	if st, ok := iter.Attachment.(*callState); ok {
+		st.err = nil
+	}
+}
+
+func hasIterError(iter *jsoniter.Iterator) bool {
+	return iter.Error != nil && iter.Error != io.EOF
+}
+
+// iterError returns the typed error of iter, nil if there is none
+func iterError(iter *jsoniter.Iterator) *Error {
+	if iter.Error == nil || iter.Error == io.EOF {
+		return nil
+	}
+	return typedError(iter.Attachment, iter.Error, iterOffset(iter))
+}
+
+// reportIterError is the typed version of iter.ReportError, the existing error is kept,
+// offset is where the failed value starts, the whitespaces after it are skipped
+func reportIterError(iter *jsoniter.Iterator, offset int, err error) {
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	if input := iterInput(iter); offset >= 0 && input != nil {
+		for offset < len(input) && strings.IndexByte(" \t\r\n", input[offset]) >= 0 {
+			offset++
+		}
+	}
+	setIterError(iter, &Error{Offset: offset, Err: err})
+}
+
+// reportSyntaxError is the typed version of iter.ReportError for the unexpected token which is just read
+func reportSyntaxError(iter *jsoniter.Iterator, operation string, msg string) {
+	offset := iterOffset(iter)
+	if offset > 0 {
+		offset--
+	}
+	reportIterError(iter, offset, fmt.Errorf("%s: %s", operation, msg))
+}
+
+// transferIterError moves the error of subIter to iter and returns it, the existing error of iter is kept,
+// offsetOf maps an offset of subIter to the input of iter, nil means the offset is unknown
+func transferIterError(iter *jsoniter.Iterator, subIter *jsoniter.Iterator, offsetOf func(int) int) *Error {
+	e := iterError(subIter)
+	if e == nil || (iter.Error != nil && iter.Error != io.EOF) {
+		return nil
+	}
+	if e.Offset >= 0 && offsetOf != nil {
+		e.Offset = offsetOf(e.Offset)
+	} else {
+		e.Offset = -1
+	}
+	setIterError(iter, e)
+	return e
+}
+
+// offsetFrom returns the function for transferIterError which maps the offset of a sub buffer starting at base
+func offsetFrom(base int) func(int) int {
+	if base < 0 {
+		return nil
+	}
+	return func(offset int) int {
+		return base + offset
+	}
+}
+
+// offsetMapping maps the offsets of a buffer rebuilt from the pieces of the input,
+// an offset between the pieces is mapped to the start of the next one
+type offsetMapping struct {
+	unknown bool
+	pieces  []struct{ from, to, n int }
+}
+
+// add records that n bytes at from of the buffer are copied from to of the input
+func (m *offsetMapping) add(from, to, n int) {
+	if to < 0 {
+		m.unknown = true
+	}
+	m.pieces = append(m.pieces, struct{ from, to, n int }{from, to, n})
+}
+
+// offsetOf returns the function for transferIterError, nil if the offsets of the input are unknown
+func (m *offsetMapping) offsetOf() func(int) int {
+	if m.unknown || len(m.pieces) <= 0 {
+		return nil
+	}
+	return func(offset int) int {
+		for _, p := range m.pieces {
+			if offset < p.from {
+				return p.to
+			}
+			if offset <= p.from+p.n {
+				return p.to + offset - p.from
+			}
+		}
+		last := m.pieces[len(m.pieces)-1]
+		return last.to + last.n
+	}
+}
+
+func setStreamError(stream *jsoniter.Stream, e *Error) {
+	stream.Error = e
+	if st, ok := stream.Attachment.(*callState); ok {
+		st.err = e
+	}
+}
+
+func hasStreamError(stream *jsoniter.Stream) bool {
+	return stream.Error != nil && stream.Error != io.EOF
+}
+
+// streamError returns the typed error of stream, nil if there is none
+func streamError(stream *jsoniter.Stream) *Error {
+	if stream.Error == nil || stream.Error == io.EOF {
+		return nil
+	}
+	return typedError(stream.Attachment, stream.Error, -1)
+}
+
+// reportStreamError sets a typed error to stream, the existing error is kept
+func reportStreamError(stream *jsoniter.Stream, err error) {
+	if stream.Error != nil && stream.Error != io.EOF {
+		return
+	}
+	setStreamError(stream, &Error{Offset: -1, Err: err})
+}
+
+// transferStreamError moves the error of subStream to stream
+func transferStreamError(stream *jsoniter.Stream, subStream *jsoniter.Stream) {
+	if e := streamError(subStream); e != nil {
+		setStreamError(stream, e)
+	}
+}
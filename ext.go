@@ -2,10 +2,12 @@ package jsoniterpb
 
 import (
 	"strings"
+	"sync"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/modern-go/reflect2"
 	"github.com/molon/jsoniterpb/extra"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
@@ -13,18 +15,79 @@ type ProtoExtension struct {
 	jsoniter.DummyExtension
 
 	EmitUnpopulated bool
-	UseEnumNumbers  bool
-	UseProtoNames   bool
-	Resolver        interface {
+	// EmitDefaultValues emits the zero values of the scalar fields without presence, the repeated fields and the map fields,
+	// unlike EmitUnpopulated, the unset fields with presence, e.g. message fields and optional fields, are not emitted as null.
+	// EmitUnpopulated takes precedence if both are set.
+	EmitDefaultValues bool
+	// EmitPolicy decides how to marshal each unpopulated field if it is set, e.g. always emitting the fields of some messages,
+	// message is the full name of the message containing fd, and EmitPolicyDefault follows EmitUnpopulated and EmitDefaultValues.
+	// It is called once for each field of the generated messages, but for each marshaling of the messages handled via protoreflect,
+	// e.g. dynamicpb.Message, so it should be cheap.
+	EmitPolicy     func(fd protoreflect.FieldDescriptor, message protoreflect.FullName) EmitPolicy
+	UseEnumNumbers bool
+	UseProtoNames  bool
+	// FieldNamer returns the name of a field for the output, which takes the place of the json name or the proto name,
+	// e.g. PascalCase or "_id" for "id", it is also accepted for the input, and the default name is used if it returns empty.
+	// It is applied to the members of oneof, the paths of google.protobuf.FieldMask and the paths of errors, but not extension fields.
+	// For each segment of the paths of google.protobuf.FieldMask, whose message is unknown, fd has the names of the segment,
+	// and the others of google.protobuf.FieldMask.paths, a path is rejected if its names can not be converted back.
+	FieldNamer func(fd protoreflect.FieldDescriptor) string
+	// FieldAliases adds the names accepted for the input by the full names of the fields, e.g. the old names of the renamed fields,
+	// the aliases are never emitted, and the message is rejected if an alias is a name of another field or one of its aliases.
+	FieldAliases map[protoreflect.FullName][]string
+	// FieldAliasOption is a custom option of google.protobuf.FieldOptions whose value is string or []string,
+	// which adds the names accepted for the input in the same way as FieldAliases.
+	FieldAliasOption protoreflect.ExtensionType
+	// Resolver is used for looking up types when expanding google.protobuf.Any messages
+	// and for resolving extension fields, e.g. "[pkg.ext]".
+	Resolver interface {
 		protoregistry.MessageTypeResolver
-		// TIPS: does not support it now
 		protoregistry.ExtensionTypeResolver
 	}
 
 	Encode64BitAsInteger bool
 	SortMapKeysAsString  bool
 	PermitInvalidUTF8    bool
-	DisableFuzzyDecode   bool
+	// DisableFuzzyDecode disables all of the fuzzy decode rules.
+	DisableFuzzyDecode bool
+	// FuzzyDecodeOptions enables the fuzzy decode rules individually, all of them are enabled if it is zero.
+	FuzzyDecodeOptions FuzzyDecodeOptions
+
+	// AllowPartial disables the checking of missing required fields when marshaling and unmarshaling.
+	AllowPartial bool
+	// DuplicateFields specifies whether to reject the fields and the map keys which appear more than once when unmarshaling,
+	// they are accepted and the last one wins by default.
+	DuplicateFields DuplicateFieldsPolicy
+	// ProtojsonErrors makes the messages of unmarshaling errors the same as protojson, e.g. `(line 1:13): invalid UTF-8 in string`,
+	// which costs unmarshaling the failed message again with protojson, then Path, FieldName and Offset are of the failure protojson finds.
+	ProtojsonErrors bool
+	// OpaqueAny marshals google.protobuf.Any whose type can not be resolved to {"@type":url,"@value":"<base64 of the value>"} instead of failing,
+	// and unmarshals it back as is, the value is checked by unmarshaling it if the type can be resolved.
+	OpaqueAny bool
+	// AllowedAnyTypes restricts the types which may be unmarshaled inside google.protobuf.Any,
+	// each of them is a type URL, e.g. "type.googleapis.com/pkg.v1.Msg", or a prefix of the full names, e.g. "pkg.v1",
+	// all of the types are allowed if it is empty.
+	AllowedAnyTypes []string
+	// AllowedAnyTypeURLPrefixes restricts the prefixes of the type URLs which may be unmarshaled inside google.protobuf.Any,
+	// e.g. "type.googleapis.com/", all of the prefixes are allowed if it is empty.
+	AllowedAnyTypeURLPrefixes []string
+	// AnyTypeURLPrefix replaces the prefixes of the type URLs when marshaling google.protobuf.Any if it is set,
+	// e.g. "types.example.com/" marshals "type.googleapis.com/pkg.v1.Msg" as "types.example.com/pkg.v1.Msg".
+	AnyTypeURLPrefix string
+	// AnyResolvers looks up the types inside google.protobuf.Any by the prefixes of the type URLs, e.g. "types.example.com/",
+	// the type whose prefix is not in it is looked up with Resolver.
+	AnyResolvers map[string]interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+	// Strict accepts exactly the input protojson accepts when unmarshaling,
+	// it overrides DisableFuzzyDecode, FuzzyDecodeOptions and DuplicateFields.
+	// The field names are case sensitive, it is faster with CaseSensitive of jsoniter.Config, otherwise the messages are decoded via protoreflect.
+	Strict bool
+
+	// fieldOptions and fieldsByName cache the options and the names of the fields by the descriptors
+	fieldOptions sync.Map
+	fieldsByName sync.Map
 }
 
 func (e *ProtoExtension) GetResolver() interface {
@@ -37,6 +100,18 @@ func (e *ProtoExtension) GetResolver() interface {
 	return protoregistry.GlobalTypes
 }
 
+// resolverOf returns the resolver of the call if it is given by MarshalOptions or UnmarshalOptions, or GetResolver if there is none,
+// attachment is the Attachment of the stream or the iterator
+func (e *ProtoExtension) resolverOf(attachment interface{}) interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+} {
+	if st, ok := attachment.(*callState); ok && st.resolver != nil {
+		return st.resolver
+	}
+	return e.GetResolver()
+}
+
 func (e *ProtoExtension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
 	if enc := e.createProtoEncoder(typ); enc != nil {
 		return enc
@@ -44,6 +119,9 @@ func (e *ProtoExtension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
 	if enc := e.createProtoEnumEncoder(typ); enc != nil {
 		return enc
 	}
+	if enc := e.createProtoReflectMessageEncoder(typ); enc != nil {
+		return enc
+	}
 	return nil
 }
 
@@ -54,6 +132,9 @@ func (e *ProtoExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
 	if dec := e.createProtoEnumDecoder(typ); dec != nil {
 		return dec
 	}
+	if dec := e.createProtoReflectMessageDecoder(typ); dec != nil {
+		return dec
+	}
 	return nil
 }
 
@@ -61,33 +142,66 @@ func (e *ProtoExtension) UpdateMapEncoderConstructor(v *jsoniter.MapEncoderConst
 	e.updateMapEncoderConstructorForNonNull(v)
 	e.updateMapEncoderConstructorForSortMapKeys(v)
 	e.updateMapEncoderConstructorForScalar(v)
+	e.updateMapEncoderConstructorForErrorPath(v)
 }
 
 func (e *ProtoExtension) UpdateSliceEncoderConstructor(v *jsoniter.SliceEncoderConstructor) {
 	e.updateSliceEncoderConstructorForNonNull(v)
+	e.updateSliceEncoderConstructorForErrorPath(v)
 }
 
 func (e *ProtoExtension) UpdateArrayEncoderConstructor(v *jsoniter.ArrayEncoderConstructor) {
 	e.updateArrayEncoderConstructorForNonNull(v)
+	e.updateArrayEncoderConstructorForErrorPath(v)
 }
 
 func (e *ProtoExtension) DecorateEncoder(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if enc := e.decorateEncoderForErrorPath(typ, encoder); enc != nil {
+		encoder = enc
+	}
 	if enc := e.decorateEncoderForNilCollection(typ, encoder); enc != nil {
 		encoder = enc
 	}
 	if enc := e.decorateEncoderForScalar(typ, encoder); enc != nil {
 		encoder = enc
 	}
+	if enc := e.decorateEncoderForExtensionFields(typ, encoder); enc != nil {
+		encoder = enc
+	}
+	if enc := e.decorateEncoderForRequired(typ, encoder); enc != nil {
+		encoder = enc
+	}
+	if enc := e.decorateEncoderForIndention(typ, encoder); enc != nil {
+		encoder = enc
+	}
 	return encoder
 }
 
 func (e *ProtoExtension) DecorateDecoder(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if dec := e.decorateDecoderForErrorPath(typ, decoder); dec != nil {
+		decoder = dec
+	}
 	if dec := e.decorateDecoderForNil(typ, decoder); dec != nil {
 		decoder = dec
 	}
 	if dec := e.decorateDecoderForScalar(typ, decoder); dec != nil {
 		decoder = dec
 	}
+	if dec := e.decorateDecoderForDuplicateFields(typ, decoder); dec != nil {
+		decoder = dec
+	}
+	if dec := e.decorateDecoderForFieldAliases(typ, decoder); dec != nil {
+		decoder = dec
+	}
+	if dec := e.decorateDecoderForRequired(typ, decoder); dec != nil {
+		decoder = dec
+	}
+	if dec := e.decorateDecoderForStrict(typ, decoder); dec != nil {
+		decoder = dec
+	}
+	if dec := e.decorateDecoderForProtojsonErrors(typ, decoder); dec != nil {
+		decoder = dec
+	}
 	return decoder
 }
 
@@ -95,8 +209,11 @@ func (e *ProtoExtension) UpdateStructDescriptorConstructor(c *jsoniter.StructDes
 	e.updateStructDescriptorConstructorForOneOf(c)
 }
 
-// Handle EmitUnpopulated and UseProtoNames
+// Handle EmitUnpopulated, EmitDefaultValues, EmitPolicy, UseProtoNames, FieldNamer, the field options, proto2 field presence, duplicate fields, duplicate map keys, null elements, unknown enums and the path of errors
 func (e *ProtoExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor) {
+	defer e.updateStructDescriptorForErrorPath(desc)
+
+	md := protoMessageDescriptor(desc.Type)
 	for _, binding := range desc.Fields {
 		if len(binding.FromNames) <= 0 { // simple check should exported
 			continue
@@ -108,11 +225,8 @@ func (e *ProtoExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor)
 			continue
 		}
 
-		if e.EmitUnpopulated {
-			binding.Encoder = &extra.EmitEmptyEncoder{binding.Encoder}
-		}
-
 		var name, jsonName string
+		var isProto3, isRepeated, isOneof bool
 		tagParts := strings.Split(tag, ",")
 		for _, part := range tagParts {
 			colons := strings.SplitN(part, "=", 2)
@@ -125,7 +239,57 @@ func (e *ProtoExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor)
 				}
 				continue
 			}
+			switch strings.TrimSpace(part) {
+			case "proto3":
+				isProto3 = true
+			case "rep":
+				isRepeated = true
+			case "oneof":
+				isOneof = true
+			}
 		}
+
+		// proto2 bytes field has presence, but it is not a pointer, nil means unset
+		if !isProto3 && !isRepeated && !isOneof && binding.Field.Type() == bytesType {
+			binding.Encoder = &protoPresenceBytesEncoder{binding.Encoder}
+		}
+
+		fd := fieldDescriptorOfBinding(md, binding)
+		if dec := e.createFieldDecoderForElements(fd, binding.Field.Type()); dec != nil {
+			binding.Decoder = dec
+		}
+		if e.isIgnoredField(fd) {
+			binding.FromNames = nil
+			binding.ToNames = nil
+			continue
+		}
+		if dec := e.wrapFieldDecoderForDuplicateFields(fd, binding.Decoder); dec != nil {
+			binding.Decoder = dec
+		}
+		if dec := e.wrapFieldDecoderForUnknownEnum(fd, binding.Field.Type(), binding.Decoder); dec != nil {
+			binding.Decoder = dec
+		}
+		binding.Encoder = e.wrapFieldEncoderForInt64AsNumber(fd, binding.Field.Type(), binding.Encoder)
+
+		var policy EmitPolicy
+		if fd != nil {
+			policy = e.emitPolicy(fd, md.FullName())
+		}
+		switch {
+		case policy == EmitPolicyAlways || policy == EmitPolicyNull:
+			binding.Encoder = newProtoEmitPolicyEncoder(policy, fd, binding.Encoder)
+		case policy == EmitPolicyOmitEmpty:
+			// the fields of the generated structs are tagged with omitempty
+		case e.EmitUnpopulated:
+			binding.Encoder = &extra.EmitEmptyEncoder{binding.Encoder}
+		case e.EmitDefaultValues:
+			// the fields with presence are omitted if unset, including the members of oneof
+			if fd != nil && !fd.HasPresence() {
+				binding.Encoder = &extra.EmitEmptyEncoder{binding.Encoder}
+			}
+		}
+
+		// for group field, name is the name of message type(e.g. "OptGroup"), which is the same as TextName of protojson
 		if jsonName == "" {
 			jsonName = name
 		}
@@ -145,6 +309,7 @@ func (e *ProtoExtension) UpdateStructDescriptor(desc *jsoniter.StructDescriptor)
 				}
 				binding.ToNames = []string{jsonName}
 			}
+			e.applyCustomFieldNames(binding, fd)
 		}
 	}
 }
@@ -2,23 +2,36 @@ package jsoniterpb_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	gofuzz "github.com/google/gofuzz"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/modern-go/reflect2"
 	"github.com/molon/jsoniterpb"
 	"github.com/molon/jsoniterpb/extra"
 	testv1 "github.com/molon/jsoniterpb/internal/gen/go/test/v1"
+	pb2 "github.com/molon/jsoniterpb/internal/protojson/textpb2"
+	pb3 "github.com/molon/jsoniterpb/internal/protojson/textpb3"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -235,6 +248,192 @@ func TestScalar(t *testing.T) {
 	commonCheck(t, cfg, nil, &wrapperspb.StringValue{Value: "\u0000\u0008\u2028\"\\/\b\f\n\r\t你好啊朋友"})
 }
 
+func TestFuzzyDecodeOptions(t *testing.T) {
+	for _, c := range []struct {
+		m    proto.Message
+		jsn  string
+		rule jsoniterpb.FuzzyDecodeOptions
+	}{
+		{&testv1.Singular{}, `{"i32":"1","f32":"1.5"}`, jsoniterpb.FuzzyDecodeNumericString},
+		{&testv1.Singular{}, `{"s":42}`, jsoniterpb.FuzzyDecodeNumberToString},
+		{&testv1.Singular{}, `{"f32":true,"i64":false}`, jsoniterpb.FuzzyDecodeBoolToNumber},
+		{&testv1.Singular{}, `{"bl":1}`, jsoniterpb.FuzzyDecodeNumberToBool},
+		{&testv1.Singular{}, `{"bl":"true","f64":"true"}`, jsoniterpb.FuzzyDecodeBoolString},
+		{&testv1.Repeated{}, `{"s":["a",null]}`, jsoniterpb.FuzzyDecodeNullToZero},
+		{&testv1.Repeated{}, `{"msg":[{},null]}`, jsoniterpb.FuzzyDecodeNullToZero},
+		{&testv1.Singular{}, `{"e":"1"}`, jsoniterpb.FuzzyDecodeEnumNumericString},
+		{&testv1.Singular{}, `{"i32":1e2,"u64":"1.0"}`, jsoniterpb.FuzzyDecodeExponentInteger},
+		{&testv1.Singular{}, `{"i64":" 2","u64":"3 "}`, jsoniterpb.FuzzyDecodeSpaces},
+	} {
+		enabled := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+		enabled.RegisterExtension(&jsoniterpb.ProtoExtension{FuzzyDecodeOptions: c.rule})
+		disabled := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+		disabled.RegisterExtension(&jsoniterpb.ProtoExtension{FuzzyDecodeOptions: jsoniterpb.FuzzyDecodeAll &^ c.rule})
+		for _, m := range []proto.Message{c.m, dynamicpb.NewMessage(c.m.ProtoReflect().Descriptor())} {
+			assert.Nil(t, enabled.UnmarshalFromString(c.jsn, m), c.jsn)
+			assert.NotNil(t, disabled.UnmarshalFromString(c.jsn, m), c.jsn)
+		}
+	}
+
+	// the path of null elements
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{FuzzyDecodeOptions: jsoniterpb.FuzzyDecodeAll &^ jsoniterpb.FuzzyDecodeNullToZero})
+	var perr *jsoniterpb.Error
+	assert.True(t, errors.As(cfg.UnmarshalFromString(`{"s":["a",null]}`, &testv1.Repeated{}), &perr))
+	assert.Equal(t, "s[1]", perr.Path)
+	assert.Equal(t, 10, perr.Offset)
+	assert.True(t, errors.As(cfg.UnmarshalFromString(`{"str":{"1":null}}`, &testv1.Map{}), &perr))
+	assert.Equal(t, `str["1"]`, perr.Path)
+	// null of the singular field is unset
+	assert.Nil(t, cfg.UnmarshalFromString(`{"s":null,"i32":null}`, &testv1.Singular{}))
+}
+
+func TestFuzzyDecodeCollector(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	jsn := `{
+		"s":{"i32":"1","e":"1","s":"canonical"},
+		"r":{"i32":[1," 2",null],"msg":[{},null],"bl":[true,1]},
+		"m":{"en":{"a":"1"},"str":{"1":2,"2":null}},
+		"oF":{"i32":true},
+		"wkt":{"i32":"3","a":{"@type":"type.googleapis.com/test.v1.Singular","u32":1.0}}
+	}`
+	want := []jsoniterpb.FuzzyDecodeReport{
+		{Path: "s.i32", FieldName: "test.v1.Singular.i32", Rule: jsoniterpb.FuzzyDecodeNumericString},
+		{Path: "s.e", FieldName: "test.v1.Singular.e", Rule: jsoniterpb.FuzzyDecodeEnumNumericString},
+		{Path: "r.i32[1]", FieldName: "test.v1.Repeated.i32", Rule: jsoniterpb.FuzzyDecodeNumericString},
+		{Path: "r.i32[1]", FieldName: "test.v1.Repeated.i32", Rule: jsoniterpb.FuzzyDecodeSpaces},
+		{Path: "r.i32[2]", FieldName: "test.v1.Repeated.i32", Rule: jsoniterpb.FuzzyDecodeNullToZero},
+		{Path: "r.msg[1]", FieldName: "test.v1.Repeated.msg", Rule: jsoniterpb.FuzzyDecodeNullToZero},
+		{Path: "r.bl[1]", FieldName: "test.v1.Repeated.bl", Rule: jsoniterpb.FuzzyDecodeNumberToBool},
+		{Path: `m.en["a"]`, FieldName: "test.v1.Map.en", Rule: jsoniterpb.FuzzyDecodeEnumNumericString},
+		{Path: `m.str["1"]`, FieldName: "test.v1.Map.str", Rule: jsoniterpb.FuzzyDecodeNumberToString},
+		{Path: `m.str["2"]`, FieldName: "test.v1.Map.str", Rule: jsoniterpb.FuzzyDecodeNullToZero},
+		{Path: "oF.i32", FieldName: "test.v1.OneOf.i32", Rule: jsoniterpb.FuzzyDecodeBoolToNumber},
+		{Path: "wkt.i32", FieldName: "test.v1.WKTs.i32", Rule: jsoniterpb.FuzzyDecodeNumericString},
+		{Path: "wkt.a.u32", FieldName: "test.v1.Singular.u32", Rule: jsoniterpb.FuzzyDecodeExponentInteger},
+	}
+	for _, m := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+		c := &jsoniterpb.FuzzyDecodeCollector{}
+		iter := cfg.BorrowIterator([]byte(jsn))
+		iter.Attachment = c
+		iter.ReadVal(m)
+		assert.Nil(t, iter.Error)
+		cfg.ReturnIterator(iter)
+		// the null elements are found before the elements are decoded
+		assert.ElementsMatch(t, want, c.Reports)
+	}
+
+	// OnReport takes the reports instead
+	var rules []string
+	c := &jsoniterpb.FuzzyDecodeCollector{
+		OnReport: func(r jsoniterpb.FuzzyDecodeReport) {
+			rules = append(rules, r.Rule.String())
+		},
+	}
+	iter := cfg.BorrowIterator([]byte(`{"i64":" 2","bl":"true"}`))
+	iter.Attachment = c
+	iter.ReadVal(&testv1.Singular{})
+	assert.Nil(t, iter.Error)
+	// the attachment is put back after decoding
+	assert.Equal(t, c, iter.Attachment)
+	cfg.ReturnIterator(iter)
+	assert.Equal(t, []string{"Spaces", "BoolString"}, rules)
+	assert.Empty(t, c.Reports)
+
+	// nothing is reported for the canonical input
+	c = &jsoniterpb.FuzzyDecodeCollector{}
+	iter = cfg.BorrowIterator([]byte(`{"s":{"i32":1,"i64":"2","e":"JSON_ENUM_SOME","s":null},"r":{"i32":[1,2]}}`))
+	iter.Attachment = c
+	iter.ReadVal(&testv1.All{})
+	assert.Nil(t, iter.Error)
+	cfg.ReturnIterator(iter)
+	assert.Empty(t, c.Reports)
+	assert.Equal(t, "NumericString|Spaces", (jsoniterpb.FuzzyDecodeNumericString | jsoniterpb.FuzzyDecodeSpaces).String())
+}
+
+func TestStrict(t *testing.T) {
+	for _, c := range []struct{ discardUnknown, caseSensitive bool }{{false, true}, {true, true}, {false, false}, {true, false}} {
+		discardUnknown := c.discardUnknown
+		cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: !discardUnknown, CaseSensitive: c.caseSensitive}.Froze()
+		cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Strict: true})
+		for _, c := range []struct {
+			m   proto.Message
+			jsn string
+		}{
+			{&testv1.Singular{}, `{"i32":"1","f32":"1.5","i64":"2","f64":"-Infinity"}`},
+			{&testv1.Singular{}, `{"i32":1e2,"u64":"1.0"}`},
+			{&testv1.Singular{}, `{"i32":1.5}`},
+			{&testv1.Singular{}, `{"s":42}`},
+			{&testv1.Singular{}, `{"f32":true}`},
+			{&testv1.Singular{}, `{"bl":1}`},
+			{&testv1.Singular{}, `{"bl":"true"}`},
+			{&testv1.Singular{}, `{"e":1,"s":null,"i32":null}`},
+			{&testv1.Singular{}, `{"i64":" 2"}`},
+			{&testv1.Singular{}, `{"i32":""}`},
+			{&testv1.Singular{}, `{"i64":""}`},
+			{&testv1.Singular{}, `{"u64":""}`},
+			{&testv1.Singular{}, `{"f32":""}`},
+			{&testv1.Singular{}, `{"bl":""}`},
+			{&testv1.Singular{}, `{"i32":1,"i32":2}`},
+			{&testv1.Singular{}, `{"I32":1}`},
+			{&testv1.Singular{}, `{"i32":1,"I32":2}`},
+			{&testv1.Singular{}, `null`},
+			{&testv1.Repeated{}, `{"s":["a",null]}`},
+			{&testv1.Repeated{}, `{"msg":[{},null]}`},
+			{&testv1.Map{}, `{"str":{"1":"a","01":"b"}}`},
+			{&testv1.All{}, `{"oF":{"sTr":"a","i32":1}}`},
+		} {
+			for _, m := range []proto.Message{c.m, dynamicpb.NewMessage(c.m.ProtoReflect().Descriptor())} {
+				want := protojson.UnmarshalOptions{DiscardUnknown: discardUnknown}.Unmarshal([]byte(c.jsn), proto.Clone(m))
+				err := cfg.UnmarshalFromString(c.jsn, proto.Clone(m))
+				assert.Equal(t, want == nil, err == nil, "%s: %v", c.jsn, err)
+			}
+		}
+	}
+
+	// the numeric string is an unknown enum name, which is only ignored with DiscardUnknown
+	assert.NotNil(t, jsoniterpb.UnmarshalOptions{Strict: true}.Unmarshal([]byte(`{"e":"1"}`), &testv1.Singular{}))
+	m := &testv1.Singular{}
+	assert.Nil(t, jsoniterpb.UnmarshalOptions{Strict: true, DiscardUnknown: true}.Unmarshal([]byte(`{"e":"1"}`), m))
+	assert.Equal(t, testv1.JsonEnum_JSON_ENUM_UNSPECIFIED, m.E)
+
+	// the name matched case insensitively is unknown, even if CaseSensitive of jsoniter.Config is not set
+	for _, caseSensitive := range []bool{true, false} {
+		cfg := jsoniter.Config{SortMapKeys: true, CaseSensitive: caseSensitive}.Froze()
+		cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Strict: true})
+		for _, m := range []proto.Message{&testv1.Singular{}, dynamicpb.NewMessage((&testv1.Singular{}).ProtoReflect().Descriptor()), &testv1.All{}} {
+			jsn := `{"I32":1,"s":"a","S":"b"}`
+			if _, ok := m.(*testv1.All); ok {
+				jsn = `{"s":{"I32":1,"s":"a","S":"b"}}`
+			}
+			assert.Nil(t, cfg.UnmarshalFromString(jsn, m))
+			s := m.ProtoReflect()
+			if _, ok := m.(*testv1.All); ok {
+				s = m.(*testv1.All).S.ProtoReflect()
+			}
+			fds := s.Descriptor().Fields()
+			assert.Equal(t, "a", s.Get(fds.ByName("s")).String())
+			assert.False(t, s.Has(fds.ByName("i32")))
+		}
+	}
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Strict: true})
+	perr := &jsoniterpb.Error{}
+	assert.True(t, errors.As(cfg.UnmarshalFromString(`{"s":{"I32":1}}`, &testv1.All{}), &perr))
+	assert.Equal(t, "s", perr.Path)
+	assert.Contains(t, perr.Err.Error(), "unknown field")
+
+	// fuzzy decode is still there without Strict
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	assert.Nil(t, cfg.UnmarshalFromString(`{"s":42,"e":"1","I32":1}`, &testv1.Singular{}))
+	assert.Nil(t, cfg.UnmarshalFromString(`{"i32":"","i64":""}`, &testv1.Singular{}))
+	assert.Nil(t, jsoniterpb.UnmarshalOptions{}.Unmarshal([]byte(`{"S":"a"}`), &testv1.Singular{}))
+	assert.NotNil(t, jsoniterpb.UnmarshalOptions{Strict: true}.Unmarshal([]byte(`{"S":"a"}`), &testv1.Singular{}))
+}
+
 func TestEmitUnpopulated(t *testing.T) {
 	lv, _ := structpb.NewList([]interface{}{
 		nil,
@@ -390,6 +589,12 @@ func TestNullValue(t *testing.T) {
 	assert.True(t, ok)
 	_, ok = wktV.V.GetKind().(*structpb.Value_NullValue)
 	assert.True(t, ok)
+
+	// NullValue is always null
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{UseEnumNumbers: true})
+	jsn = commonCheck(t, cfg, &protojson.MarshalOptions{UseEnumNumbers: true}, m)
+	assert.Contains(t, jsn, `"nu":null`)
 }
 
 func TestEnum(t *testing.T) {
@@ -469,6 +674,55 @@ func TestEnum(t *testing.T) {
 	assert.Equal(t, jsnA, jsnB)
 }
 
+func TestDiscardUnknownEnums(t *testing.T) {
+	// the unknown enum names are ignored like unknown fields
+	cfg := jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	jsn := `{
+		"e":"NEW",
+		"o":{"e":"NEW","i32":1},
+		"oF":{"e":"NEW"},
+		"r":{"e":["NEW","JSON_ENUM_SOME" , "NEW",1,"NEW"]},
+		"m":{"en":{"a":"NEW","b":"JSON_ENUM_SOME","c":"NEW"}}
+	}`
+	want := &testv1.All{
+		O:  &testv1.Optionals{I32: proto.Int32(1)},
+		OF: &testv1.OneOf{},
+		R:  &testv1.Repeated{E: []testv1.JsonEnum{testv1.JsonEnum_JSON_ENUM_SOME, testv1.JsonEnum_JSON_ENUM_SOME}},
+		M:  &testv1.Map{En: map[string]testv1.JsonEnum{"b": testv1.JsonEnum_JSON_ENUM_SOME}},
+	}
+	for _, m := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(jsn, m))
+		assert.True(t, proto.Equal(want, m), protojson.Format(m))
+
+		// the index of the input is in the path of the error
+		var perr *jsoniterpb.Error
+		assert.True(t, errors.As(cfg.UnmarshalFromString(`{"r":{"e":["NEW",{}]}}`, m), &perr))
+		assert.Equal(t, "r.e[1]", perr.Path)
+	}
+
+	// extensions
+	m := &pb2.Extensions{}
+	assert.Nil(t, cfg.UnmarshalFromString(`{"[pb2.opt_ext_enum]":"NEW","[pb2.rpt_ext_enum]":["NEW","TEN"]}`, m))
+	assert.False(t, proto.HasExtension(m, pb2.E_OptExtEnum))
+	assert.Equal(t, []pb2.Enum{pb2.Enum_TEN}, proto.GetExtension(m, pb2.E_RptExtEnum))
+
+	// the numeric strings are still accepted by the fuzzy decode
+	m2 := &testv1.Repeated{}
+	assert.Nil(t, cfg.UnmarshalFromString(`{"e":["1","NEW"]}`, m2))
+	assert.Equal(t, []testv1.JsonEnum{testv1.JsonEnum_JSON_ENUM_SOME}, m2.E)
+
+	// rejected if DisallowUnknownFields
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	for _, jsn := range []string{`{"e":"NEW"}`, `{"o":{"e":"NEW"}}`, `{"r":{"e":["NEW"]}}`, `{"m":{"en":{"a":"NEW"}}}`} {
+		assert.NotNil(t, cfg.UnmarshalFromString(jsn, &testv1.All{}), jsn)
+		assert.NotNil(t, cfg.UnmarshalFromString(jsn, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())), jsn)
+	}
+	assert.Nil(t, jsoniterpb.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(`{"e":"NEW"}`), &testv1.All{}))
+}
+
 func TestInteger64AsString(t *testing.T) {
 	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
 	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
@@ -681,6 +935,35 @@ func TestOneof(t *testing.T) {
 	assert.Equal(t, "extraS2", em.OneOf.Extra)
 	assert.Equal(t, int32(223), em.OneOf.GetI32())
 
+	// more than one member, even if the duplicate fields are accepted
+	for _, disallowUnknownFields := range []bool{true, false} {
+		cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: disallowUnknownFields}.Froze()
+		cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+		for _, v := range []proto.Message{&testv1.OneOf{}, dynamicpb.NewMessage((&testv1.OneOf{}).ProtoReflect().Descriptor())} {
+			err = cfg.UnmarshalFromString(`{"sTr":"a","i32":4}`, v)
+			assert.Contains(t, err.Error(), `error parsing "i32", oneof test.v1.OneOf.one_of is already set`)
+			err = cfg.UnmarshalFromString(`{"sTr":"a","sTr":"b"}`, v)
+			assert.Contains(t, err.Error(), `error parsing "sTr", oneof test.v1.OneOf.one_of is already set`)
+		}
+		err = cfg.UnmarshalFromString(`{"oF":{"msg":{},"bl":true}}`, &testv1.All{})
+		assert.Contains(t, err.Error(), `error parsing "bl", oneof test.v1.OneOf.one_of is already set`)
+	}
+	// null means unset
+	m2 := &testv1.OneOf{}
+	err = cfg.UnmarshalFromString(`{"sTr":"a","i32":null,"msg":null}`, m2)
+	assert.Nil(t, err)
+	assert.True(t, ProtoEqual(&testv1.OneOf{OneOf: &testv1.OneOf_STr{STr: "a"}}, m2))
+	m2 = &testv1.OneOf{}
+	err = cfg.UnmarshalFromString(`{"i32":null}`, m2)
+	assert.Nil(t, err)
+	assert.Nil(t, m2.OneOf)
+	// the oneof of an existing message could be replaced
+	err = cfg.UnmarshalFromString(`{"i32":1}`, m2)
+	assert.Nil(t, err)
+	err = cfg.UnmarshalFromString(`{"sTr":"b"}`, m2)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", m2.GetSTr())
+
 	// special wkt
 	jsn, err = cfg.MarshalToString(structpb.NewStringValue("structpb.StrValue"))
 	assert.Nil(t, err)
@@ -745,6 +1028,1151 @@ func TestAny(t *testing.T) {
 	// miss type
 	err = cfg.UnmarshalFromString(`{"wkt":{"a":{"name":"s"}}}`, m)
 	assert.Contains(t, err.Error(), "google.protobuf.Any: missing \"@type\" field")
+	err = cfg.UnmarshalFromString(`{"wkt":{"a":{"@type":"type.googleapis.com/test.v1.New","name":"s"}}}`, m)
+	assert.Contains(t, err.Error(), "google.protobuf.Any: unable to resolve \"type.googleapis.com/test.v1.New\"")
+
+	// the fields are unknown with DiscardUnknown if the type is missing or can not be resolved
+	cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	for _, jsn := range []string{
+		`{"wkt":{"a":{"name":"s"},"i32":1},"rWkt":{"a":[{"value":1},{}]}}`,
+		`{"wkt":{"a":{"@type":"type.googleapis.com/test.v1.New","name":"s"},"i32":1},"rWkt":{"a":[{"@type":"type.googleapis.com/test.v1.New"},{}]}}`,
+	} {
+		for _, m := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+			assert.Nil(t, cfg.UnmarshalFromString(jsn, m), jsn)
+			assert.True(t, proto.Equal(&testv1.All{
+				Wkt:  &testv1.WKTs{A: &anypb.Any{}, I32: wrapperspb.Int32(1)},
+				RWkt: &testv1.RepeatedWKTs{A: []*anypb.Any{{}, {}}},
+			}, m), protojson.Format(m))
+		}
+	}
+	err = cfg.UnmarshalFromString(`{"wkt":{"a":{"@type":""}}}`, m)
+	assert.Contains(t, err.Error(), `google.protobuf.Any: "@type" field contains empty value`)
+	assert.Nil(t, jsoniterpb.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(`{"@type":"type.googleapis.com/test.v1.New"}`), &anypb.Any{}))
+
+	// the type which can not be resolved is passed through with OpaqueAny
+	a, _ := anypb.New(&testv1.Message{Id: "idA"})
+	m = &testv1.All{Wkt: &testv1.WKTs{A: a}, RWkt: &testv1.RepeatedWKTs{A: []*anypb.Any{a}}}
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: new(protoregistry.Types)})
+	_, err = cfg.MarshalToString(m)
+	assert.Contains(t, err.Error(), `google.protobuf.Any: unable to resolve "type.googleapis.com/test.v1.Message"`)
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: new(protoregistry.Types), OpaqueAny: true})
+	jsn, err := cfg.MarshalToString(m)
+	assert.Nil(t, err)
+	opaque := `{"@type":"type.googleapis.com/test.v1.Message","@value":"` + base64.StdEncoding.EncodeToString(a.Value) + `"}`
+	assert.Equal(t, `{"wkt":{"a":`+opaque+`},"rWkt":{"a":[`+opaque+`]}}`, jsn)
+	for _, m2 := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(jsn, m2))
+		assert.True(t, proto.Equal(m, m2))
+	}
+
+	// the resolved types are the same as before
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{OpaqueAny: true})
+	commonCheck(t, cfg, nil, m)
+	var perr *jsoniterpb.Error
+	assert.True(t, errors.As(cfg.UnmarshalFromString(`{"wkt":{"a":{"@type":"type.googleapis.com/test.v1.Message","@value":"!"}}}`, m), &perr))
+	assert.Equal(t, "wkt.a.@value", perr.Path)
+	// the value is checked if the type can be resolved, and it is passed through as is if not
+	m2 := &testv1.All{}
+	assert.Nil(t, cfg.UnmarshalFromString(`{"wkt":{"a":`+opaque+`}}`, m2))
+	assert.True(t, proto.Equal(&testv1.All{Wkt: &testv1.WKTs{A: a}}, m2))
+	invalid := `{"wkt":{"a":{"@type":"type.googleapis.com/test.v1.Message","@value":"` + base64.StdEncoding.EncodeToString([]byte{0x0a, 0x05}) + `"}}}`
+	perr = nil
+	assert.True(t, errors.As(cfg.UnmarshalFromString(invalid, m2), &perr))
+	assert.Equal(t, "wkt.a.@value", perr.Path)
+	assert.Equal(t, strings.Index(invalid, `"CgU="`), perr.Offset)
+	assert.Contains(t, perr.Err.Error(), `google.protobuf.Any: unable to unmarshal "type.googleapis.com/test.v1.Message"`)
+	cfgUnresolved := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfgUnresolved.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: new(protoregistry.Types), OpaqueAny: true})
+	assert.Nil(t, cfgUnresolved.UnmarshalFromString(invalid, m2))
+	assert.Equal(t, []byte{0x0a, 0x05}, m2.Wkt.A.Value)
+
+	// only the allowed types are unmarshaled inside Any, at any depth
+	inner, _ := anypb.New(&testv1.Message{Id: "idA"})
+	outer, _ := anypb.New(inner)
+	m = &testv1.All{Wkt: &testv1.WKTs{A: outer}}
+	jsn, err = cfg.MarshalToString(m)
+	assert.Nil(t, err)
+	for _, allowed := range [][]string{
+		{"google.protobuf.Any", "test.v1"},
+		{"google.protobuf.", "type.googleapis.com/test.v1.Message"},
+	} {
+		cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+		cfg.RegisterExtension(&jsoniterpb.ProtoExtension{AllowedAnyTypes: allowed})
+		for _, m2 := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+			assert.Nil(t, cfg.UnmarshalFromString(jsn, m2), allowed)
+			assert.True(t, proto.Equal(m, m2), allowed)
+		}
+	}
+	for _, allowed := range [][]string{
+		{"google.protobuf.Any"},
+		{"google.protobuf.Any", "test.v"},
+		{"google.protobuf.Any", "type.googleapis.com/test.v1.Messages"},
+	} {
+		cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+		cfg.RegisterExtension(&jsoniterpb.ProtoExtension{AllowedAnyTypes: allowed, OpaqueAny: true})
+		for _, m2 := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+			perr = nil
+			assert.True(t, errors.As(cfg.UnmarshalFromString(jsn, m2), &perr), allowed)
+			assert.Equal(t, "wkt.a.value", perr.Path)
+			assert.Contains(t, perr.Err.Error(), `google.protobuf.Any: type "type.googleapis.com/test.v1.Message" is not allowed`)
+		}
+	}
+	cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{AllowedAnyTypes: []string{"test.v1"}})
+	err = cfg.UnmarshalFromString(jsn, m)
+	assert.Contains(t, err.Error(), `google.protobuf.Any: type "type.googleapis.com/google.protobuf.Any" is not allowed`)
+
+	// the prefixes of the type URLs are rewritten, restricted and resolved with their own resolvers
+	m = &testv1.All{Wkt: &testv1.WKTs{A: outer}, RWkt: &testv1.RepeatedWKTs{A: []*anypb.Any{inner}}}
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{AnyTypeURLPrefix: "types.example.com/"})
+	jsn, err = cfg.MarshalToString(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"wkt":{"a":{"@type":"types.example.com/google.protobuf.Any","value":{"@type":"types.example.com/test.v1.Message","id":"idA"}}},"rWkt":{"a":[{"@type":"types.example.com/test.v1.Message","id":"idA"}]}}`, jsn)
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{
+		Resolver:                  new(protoregistry.Types),
+		AllowedAnyTypeURLPrefixes: []string{"types.example.com"},
+		AnyResolvers: map[string]interface {
+			protoregistry.MessageTypeResolver
+			protoregistry.ExtensionTypeResolver
+		}{"types.example.com/": protoregistry.GlobalTypes},
+		AnyTypeURLPrefix: "type.googleapis.com",
+	})
+	for _, m2 := range []proto.Message{&testv1.All{}, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(jsn, m2))
+		jsn2, err := cfg.MarshalToString(m2)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"wkt":{"a":{"@type":"type.googleapis.com/google.protobuf.Any","value":{"@type":"type.googleapis.com/test.v1.Message","id":"idA"}}},"rWkt":{"a":[{"@type":"type.googleapis.com/test.v1.Message","id":"idA"}]}}`, jsn2)
+	}
+	perr = nil
+	assert.True(t, errors.As(cfg.UnmarshalFromString(`{"wkt":{"a":{"@type":"types.example.com/google.protobuf.Any","value":{"@type":"type.googleapis.com/test.v1.Message","id":"idA"}}}}`, m), &perr))
+	assert.Equal(t, "wkt.a.value", perr.Path)
+	assert.Contains(t, perr.Error(), `google.protobuf.Any: type URL prefix of "type.googleapis.com/test.v1.Message" is not allowed`)
+}
+
+func TestResolverFromDescriptorSet(t *testing.T) {
+	// the imports of test.proto are not in the set, which are found in protoregistry.GlobalFiles
+	fd := (&testv1.All{}).ProtoReflect().Descriptor().ParentFile()
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(fd)},
+	})
+	assert.Nil(t, err)
+	types, err := jsoniterpb.NewResolverFromDescriptorSet(b)
+	assert.Nil(t, err)
+	name := filepath.Join(t.TempDir(), "test.pb")
+	assert.Nil(t, os.WriteFile(name, b, 0o600))
+	typesFromFiles, err := jsoniterpb.NewResolverFromDescriptorSetFiles(name, name)
+	assert.Nil(t, err)
+	_, err = jsoniterpb.NewResolverFromDescriptorSet([]byte("!"))
+	assert.NotNil(t, err)
+	_, err = jsoniterpb.NewResolverFromDescriptorSetFiles(filepath.Join(t.TempDir(), "none.pb"))
+	assert.NotNil(t, err)
+
+	a, _ := anypb.New(&testv1.Message{Id: "idA"})
+	m := &testv1.All{Wkt: &testv1.WKTs{A: a}, M: &testv1.Map{Msg: map[int32]*testv1.Nested{1: {}}, An: map[uint64]*anypb.Any{2: a}}}
+	jsn, err := protojson.Marshal(m)
+	assert.Nil(t, err)
+
+	// the resolver is swapped without freezing again
+	r := jsoniterpb.NewSwappableResolver(new(protoregistry.Types))
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: r})
+	err = cfg.Unmarshal(jsn, &testv1.All{})
+	assert.Contains(t, err.Error(), `google.protobuf.Any: unable to resolve "type.googleapis.com/test.v1.Message"`)
+	for _, types := range []*protoregistry.Types{types, typesFromFiles} {
+		r.Store(types)
+		m2 := &testv1.All{}
+		assert.Nil(t, cfg.Unmarshal(jsn, m2))
+		assert.True(t, proto.Equal(m, m2))
+
+		// the whole message is dynamic
+		mt, err := r.FindMessageByName("test.v1.All")
+		assert.Nil(t, err)
+		dm := mt.New().Interface()
+		_, ok := dm.(*dynamicpb.Message)
+		assert.True(t, ok)
+		assert.Nil(t, cfg.Unmarshal(jsn, dm))
+		jsn2, err := cfg.Marshal(dm)
+		assert.Nil(t, err)
+		assert.JSONEq(t, string(jsn), string(jsn2))
+	}
+	r.Store(nil)
+	assert.Equal(t, protoregistry.GlobalTypes, r.Load())
+}
+
+func TestProto2(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	cfgEmit := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfgEmit.RegisterExtension(&jsoniterpb.ProtoExtension{EmitUnpopulated: true})
+	cfgProtoNames := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfgProtoNames.RegisterExtension(&jsoniterpb.ProtoExtension{UseProtoNames: true})
+
+	// field presence
+	m := &pb2.Scalars{}
+	assert.Equal(t, "{}", commonCheck(t, cfg, nil, m))
+	commonCheck(t, cfgEmit, &protojson.MarshalOptions{EmitUnpopulated: true}, m)
+	m = &pb2.Scalars{
+		OptBool:   proto.Bool(false),
+		OptInt32:  proto.Int32(0),
+		OptInt64:  proto.Int64(0),
+		OptUint64: proto.Uint64(0),
+		OptFloat:  proto.Float32(0),
+		OptBytes:  []byte{},
+		OptString: proto.String(""),
+	}
+	jsn := commonCheck(t, cfg, nil, m)
+	assert.Equal(t, `{"optBool":false,"optInt32":0,"optInt64":"0","optUint64":"0","optFloat":0,"optBytes":"","optString":""}`, jsn)
+	commonCheck(t, cfgEmit, &protojson.MarshalOptions{EmitUnpopulated: true}, m)
+
+	m2 := &pb2.Scalars{}
+	err := cfg.UnmarshalFromString(`{"optInt32":null,"optBytes":"","optString":null}`, m2)
+	assert.Nil(t, err)
+	assert.Nil(t, m2.OptInt32)
+	assert.NotNil(t, m2.OptBytes)
+	assert.Nil(t, m2.OptString)
+
+	// default values are not emitted if not set
+	fo := &descriptorpb.FileOptions{}
+	assert.Equal(t, descriptorpb.FileOptions_SPEED, fo.GetOptimizeFor())
+	assert.Equal(t, "{}", commonCheck(t, cfg, nil, fo))
+	commonCheck(t, cfgEmit, &protojson.MarshalOptions{EmitUnpopulated: true}, fo)
+	fo.OptimizeFor = descriptorpb.FileOptions_SPEED.Enum()
+	fo.JavaPackage = proto.String("")
+	commonCheck(t, cfg, nil, fo)
+
+	// group
+	ns := &pb2.Nests{
+		Optgroup: &pb2.Nests_OptGroup{
+			OptString: proto.String("inside a group"),
+			Optnestedgroup: &pb2.Nests_OptGroup_OptNestedGroup{
+				OptFixed32: proto.Uint32(47),
+			},
+		},
+		Rptgroup: []*pb2.Nests_RptGroup{{RptString: []string{"a"}}},
+	}
+	jsn = commonCheck(t, cfg, nil, ns)
+	assert.Contains(t, jsn, `"optgroup":{`)
+	jsn = commonCheck(t, cfgProtoNames, &protojson.MarshalOptions{UseProtoNames: true}, ns)
+	assert.Contains(t, jsn, `"OptGroup":{`)
+	assert.Contains(t, jsn, `"RptGroup":[`)
+	ns2 := &pb2.Nests{}
+	err = cfg.UnmarshalFromString(`{"OptGroup":{"optString":"x"},"rptgroup":[{}]}`, ns2)
+	assert.Nil(t, err)
+	assert.Equal(t, "x", ns2.GetOptgroup().GetOptString())
+	assert.Len(t, ns2.GetRptgroup(), 1)
+
+	// optional NullValue
+	kt := &pb2.KnownTypes{}
+	err = cfg.UnmarshalFromString(`{"optNull":"NULL_VALUE"}`, kt)
+	assert.Nil(t, err)
+	assert.NotNil(t, kt.OptNull)
+	kt = &pb2.KnownTypes{}
+	err = cfg.UnmarshalFromString(`{"optNull":null}`, kt)
+	assert.Nil(t, err)
+	assert.NotNil(t, kt.OptNull)
+	commonCheck(t, cfg, nil, kt)
+}
+
+func TestEmitDefaultValues(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{EmitDefaultValues: true})
+	// EmitUnpopulated takes precedence
+	cfgEmit := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfgEmit.RegisterExtension(&jsoniterpb.ProtoExtension{EmitDefaultValues: true, EmitUnpopulated: true})
+
+	for _, tt := range []struct {
+		m   proto.Message
+		jsn string
+	}{
+		{&pb3.Scalars{}, `{"sBool":false,"sInt32":0,"sInt64":"0","sUint32":0,"sUint64":"0","sSint32":0,"sSint64":"0","sFixed32":0,"sFixed64":"0","sSfixed32":0,"sSfixed64":"0","sFloat":0,"sDouble":0,"sBytes":"","sString":""}`},
+		{&pb3.Proto3Optional{}, `{}`},
+		{&pb3.Proto3Optional{OptInt32: proto.Int32(0), OptMessage: &pb3.Nested{}}, `{"optInt32":0,"optMessage":{"sString":""}}`},
+		{&pb3.Nests{}, `{}`},
+		{&pb3.Oneofs{}, `{}`},
+		{&pb3.Oneofs{Union: &pb3.Oneofs_OneofString{}}, `{"oneofString":""}`},
+		{&pb3.Repeats{RptString: []string{""}}, `{"rptBool":[],"rptInt32":[],"rptInt64":[],"rptUint32":[],"rptUint64":[],"rptFloat":[],"rptDouble":[],"rptString":[""],"rptBytes":[]}`},
+		{&pb3.Maps{}, `{"int32ToStr":{},"boolToUint32":{},"uint64ToEnum":{},"strToNested":{},"strToOneofs":{}}`},
+		{&pb2.Scalars{}, `{}`},
+		{&pb2.Nests{}, `{"rptNested":[],"rptgroup":[]}`},
+	} {
+		dm := dynamicpb.NewMessage(tt.m.ProtoReflect().Descriptor())
+		proto.Merge(dm, tt.m)
+		for _, m := range []proto.Message{tt.m, dm} {
+			jsn, err := cfg.MarshalToString(m)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.jsn, jsn)
+			b, err := jsoniterpb.MarshalOptions{EmitDefaultValues: true}.Marshal(m)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.jsn, string(b))
+			commonCheck(t, cfgEmit, &protojson.MarshalOptions{EmitUnpopulated: true}, m)
+		}
+	}
+}
+
+func TestEmitPolicy(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{
+		EmitUnpopulated: true,
+		EmitPolicy: func(fd protoreflect.FieldDescriptor, message protoreflect.FullName) jsoniterpb.EmitPolicy {
+			switch {
+			case message == "pb3.Repeats":
+				return jsoniterpb.EmitPolicyOmitEmpty
+			case message == "pb3.Proto3Optional":
+				return jsoniterpb.EmitPolicyAlways
+			case fd.Kind() == protoreflect.StringKind || fd.Name() == "s_nested":
+				return jsoniterpb.EmitPolicyNull
+			}
+			return jsoniterpb.EmitPolicyDefault
+		},
+	})
+
+	for _, tt := range []struct {
+		m   proto.Message
+		jsn string
+	}{
+		{&pb3.Scalars{SBool: true}, `{"sBool":true,"sInt32":0,"sInt64":"0","sUint32":0,"sUint64":"0","sSint32":0,"sSint64":"0","sFixed32":0,"sFixed64":"0","sSfixed32":0,"sSfixed64":"0","sFloat":0,"sDouble":0,"sBytes":"","sString":null}`},
+		{&pb3.Scalars{SString: "s"}, `{"sBool":false,"sInt32":0,"sInt64":"0","sUint32":0,"sUint64":"0","sSint32":0,"sSint64":"0","sFixed32":0,"sFixed64":"0","sSfixed32":0,"sSfixed64":"0","sFloat":0,"sDouble":0,"sBytes":"","sString":"s"}`},
+		{&pb3.Proto3Optional{OptInt32: proto.Int32(0)}, `{"optBool":null,"optInt32":0,"optInt64":null,"optUint32":null,"optUint64":null,"optFloat":null,"optDouble":null,"optString":null,"optBytes":null,"optEnum":null,"optMessage":null}`},
+		{&pb3.Nests{}, `{"sNested":null}`},
+		{&pb3.Nests{SNested: &pb3.Nested{}}, `{"sNested":{"sString":null,"sNested":null}}`},
+		{&pb3.Oneofs{}, `{}`},
+		{&pb3.Repeats{RptBool: []bool{false}}, `{"rptBool":[false]}`},
+		{&pb2.Scalars{}, `{"optBool":null,"optInt32":null,"optInt64":null,"optUint32":null,"optUint64":null,"optSint32":null,"optSint64":null,"optFixed32":null,"optFixed64":null,"optSfixed32":null,"optSfixed64":null,"optFloat":null,"optDouble":null,"optBytes":null,"optString":null}`},
+	} {
+		dm := dynamicpb.NewMessage(tt.m.ProtoReflect().Descriptor())
+		proto.Merge(dm, tt.m)
+		for _, m := range []proto.Message{tt.m, dm} {
+			jsn, err := cfg.MarshalToString(m)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.jsn, jsn)
+		}
+	}
+}
+
+func TestFieldNamer(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{
+		FieldNamer: func(fd protoreflect.FieldDescriptor) string {
+			switch {
+			case fd.Name() == "id":
+				return "_id"
+			case fd.Name() == "fm":
+				return ""
+			}
+			// PascalCase
+			return strings.ToUpper(fd.JSONName()[:1]) + fd.JSONName()[1:]
+		},
+		DuplicateFields: jsoniterpb.DuplicateFieldsDisallow,
+	})
+
+	for _, tt := range []struct {
+		m   proto.Message
+		jsn string
+	}{
+		{&pb3.Nests{SNested: &pb3.Nested{SString: "s"}}, `{"SNested":{"SString":"s"}}`},
+		{&pb3.Oneofs{Union: &pb3.Oneofs_OneofNested{OneofNested: &pb3.Nested{SString: "s"}}}, `{"OneofNested":{"SString":"s"}}`},
+		{&pb3.Oneofs{Union: &pb3.Oneofs_OneofString{}}, `{"OneofString":""}`},
+		{&testv1.Message{Id: "idA"}, `{"_id":"idA"}`},
+		{&testv1.WKTs{Fm: &fieldmaskpb.FieldMask{Paths: []string{"id", "s_nested.s_string"}}}, `{"fm":"_id,SNested.SString"}`},
+	} {
+		dm := dynamicpb.NewMessage(tt.m.ProtoReflect().Descriptor())
+		proto.Merge(dm, tt.m)
+		for _, m := range []proto.Message{tt.m, dm} {
+			jsn, err := cfg.MarshalToString(m)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.jsn, jsn)
+			m2 := m.ProtoReflect().New().Interface()
+			assert.Nil(t, cfg.UnmarshalFromString(jsn, m2))
+			assert.True(t, proto.Equal(m, m2), jsn)
+		}
+	}
+
+	// the json names and the proto names are also accepted
+	for _, m := range []proto.Message{&pb3.Nests{}, dynamicpb.NewMessage((&pb3.Nests{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(`{"sNested":{"s_string":"s"}}`, m))
+		assert.True(t, proto.Equal(&pb3.Nests{SNested: &pb3.Nested{SString: "s"}}, m))
+		assert.Contains(t, cfg.UnmarshalFromString(`{"SNested":{"SString":"s"},"sNested":{}}`, m).Error(), `duplicate field "SNested"`)
+
+		var perr *jsoniterpb.Error
+		assert.True(t, errors.As(cfg.UnmarshalFromString(`{"SNested":{"s_string":[]}}`, m), &perr))
+		assert.Equal(t, "SNested.SString", perr.Path)
+		assert.Equal(t, protoreflect.FullName("pb3.Nested.s_string"), perr.FieldName)
+	}
+	m := &testv1.WKTs{}
+	assert.Nil(t, cfg.UnmarshalFromString(`{"fm":"_id,sNested.SString"}`, m))
+	assert.Equal(t, []string{"id", "s_nested.s_string"}, m.Fm.GetPaths())
+	err := cfg.UnmarshalFromString(`{"fm":"s_nested"}`, m)
+	assert.Contains(t, err.Error(), `google.protobuf.FieldMask.paths contains invalid path: "s_nested"`)
+	_, err = cfg.MarshalToString(&fieldmaskpb.FieldMask{Paths: []string{"foo_1bar"}})
+	assert.Contains(t, err.Error(), `google.protobuf.FieldMask.paths contains irreversible value "foo_1bar"`)
+}
+
+func TestFieldAliases(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{
+		FieldAliases: map[protoreflect.FullName][]string{
+			"pb3.Nested.s_string":   {"oldString", "old_string"},
+			"pb3.Oneofs.oneof_enum": {"oldEnum"},
+		},
+		DuplicateFields: jsoniterpb.DuplicateFieldsDisallow,
+	})
+	for _, m := range []proto.Message{&pb3.Nests{}, dynamicpb.NewMessage((&pb3.Nests{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(`{"sNested":{"oldString":"s","sNested":{"old_string":"t"}}}`, m))
+		expected := &pb3.Nests{SNested: &pb3.Nested{SString: "s", SNested: &pb3.Nested{SString: "t"}}}
+		assert.True(t, proto.Equal(expected, m))
+		jsn, err := cfg.MarshalToString(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"sNested":{"sString":"s","sNested":{"sString":"t"}}}`, jsn)
+		assert.Contains(t, cfg.UnmarshalFromString(`{"sNested":{"sString":"s","oldString":"s"}}`, m).Error(), `duplicate field "sString"`)
+	}
+	for _, m := range []proto.Message{&pb3.Oneofs{}, dynamicpb.NewMessage((&pb3.Oneofs{}).ProtoReflect().Descriptor())} {
+		assert.Nil(t, cfg.UnmarshalFromString(`{"oldEnum":"ONE"}`, m))
+		assert.True(t, proto.Equal(&pb3.Oneofs{Union: &pb3.Oneofs_OneofEnum{OneofEnum: pb3.Enum_ONE}}, m))
+		jsn, err := cfg.MarshalToString(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"oneofEnum":"ONE"}`, jsn)
+	}
+
+	// conflicting with a name of another field
+	for _, aliases := range []map[protoreflect.FullName][]string{
+		{"pb3.Nested.s_string": {"sNested"}},
+		{"pb3.Nested.s_string": {"s_nested"}},
+		{"pb3.Nested.s_string": {"old"}, "pb3.Nested.s_nested": {"old"}},
+	} {
+		cfgConflict := jsoniter.Config{}.Froze()
+		cfgConflict.RegisterExtension(&jsoniterpb.ProtoExtension{FieldAliases: aliases})
+		for _, m := range []proto.Message{&pb3.Nested{}, dynamicpb.NewMessage((&pb3.Nested{}).ProtoReflect().Descriptor())} {
+			err := cfgConflict.UnmarshalFromString(`{"sString":"s"}`, m)
+			assert.NotNil(t, err)
+			if err != nil {
+				assert.Contains(t, err.Error(), "conflicts with pb3.Nested.s_")
+			}
+		}
+	}
+
+	// by a custom option
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("alias.proto"),
+		Package:    proto.String("alias"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("aliases"),
+			Number:   proto.Int32(50000),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Extendee: proto.String(".google.protobuf.FieldOptions"),
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.Nil(t, err)
+	xt := dynamicpb.NewExtensionType(fd.Extensions().Get(0))
+	opts := &descriptorpb.FieldOptions{}
+	list := xt.New().List()
+	list.Append(protoreflect.ValueOfString("userName"))
+	proto.SetExtension(opts, xt, list)
+	fdp.MessageType = []*descriptorpb.DescriptorProto{{
+		Name: proto.String("User"),
+		Field: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("name"),
+			JsonName: proto.String("name"),
+			Number:   proto.Int32(1),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Options:  opts,
+		}},
+	}}
+	fd, err = protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	assert.Nil(t, err)
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{FieldAliasOption: xt})
+	m := dynamicpb.NewMessage(fd.Messages().Get(0))
+	assert.Nil(t, cfg.UnmarshalFromString(`{"userName":"n"}`, m))
+	jsn, err := cfg.MarshalToString(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name":"n"}`, jsn)
+}
+
+func TestFieldOptions(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	cfgEmit := jsoniter.Config{SortMapKeys: true}.Froze()
+	cfgEmit.RegisterExtension(&jsoniterpb.ProtoExtension{EmitUnpopulated: true})
+	cfgInteger := jsoniter.Config{SortMapKeys: true}.Froze()
+	cfgInteger.RegisterExtension(&jsoniterpb.ProtoExtension{Encode64BitAsInteger: true})
+
+	md := (&testv1.Annotated{}).ProtoReflect().Descriptor()
+	for _, newMessage := range []func() proto.Message{
+		func() proto.Message { return &testv1.Annotated{} },
+		func() proto.Message { return dynamicpb.NewMessage(md) },
+	} {
+		m := newMessage()
+		proto.Merge(m, &testv1.Annotated{
+			Id:       "x",
+			Password: "p",
+			Balance:  9007199254740993,
+			Counts:   []uint64{1, math.MaxUint64},
+			Totals:   map[int64]int64{-1: -2, 3: 4},
+			Union:    &testv1.Annotated_OneofU64{OneofU64: 7},
+			Plain:    5,
+		})
+		jsn, err := cfg.MarshalToString(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"_id":"x","balance":9007199254740993,"counts":[1,18446744073709551615],"totals":{"-1":-2,"3":4},"age":0,"nickname":null,"oneofU64":7,"plain":"5"}`, jsn)
+		jsn, err = cfgInteger.MarshalToString(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"_id":"x","balance":9007199254740993,"counts":[1,18446744073709551615],"totals":{"-1":-2,"3":4},"age":0,"nickname":null,"oneofU64":7,"plain":5}`, jsn)
+
+		// omit_empty takes precedence over EmitUnpopulated
+		m = newMessage()
+		jsn, err = cfgEmit.MarshalToString(m)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"_id":"","balance":0,"counts":[],"totals":{},"age":0,"nickname":null,"plain":"0"}`, jsn)
+
+		assert.Nil(t, cfg.UnmarshalFromString(`{"_id":"x","balance":1,"counts":["2",3],"totals":{"4":5},"oneofU64":"6"}`, m))
+		assert.True(t, proto.Equal(&testv1.Annotated{
+			Id:      "x",
+			Balance: 1,
+			Counts:  []uint64{2, 3},
+			Totals:  map[int64]int64{4: 5},
+			Union:   &testv1.Annotated_OneofU64{OneofU64: 6},
+		}, m))
+		m = newMessage()
+		assert.Nil(t, cfg.UnmarshalFromString(`{"id":"y"}`, m))
+		assert.True(t, proto.Equal(&testv1.Annotated{Id: "y"}, m))
+
+		// the ignored fields are unknown fields
+		assert.Contains(t, cfg.UnmarshalFromString(`{"password":"p"}`, m).Error(), "password")
+		assert.Contains(t, cfg.UnmarshalFromString(`{"oneofSecret":"s"}`, m).Error(), "oneofSecret")
+		m = newMessage()
+		assert.Nil(t, cfgEmit.UnmarshalFromString(`{"password":"p","oneofSecret":"s","note":"n"}`, m))
+		assert.True(t, proto.Equal(&testv1.Annotated{Note: "n"}, m))
+	}
+}
+
+func TestRequired(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	cfgPartial := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfgPartial.RegisterExtension(&jsoniterpb.ProtoExtension{AllowPartial: true})
+
+	m := &pb2.Requireds{
+		ReqBool:     proto.Bool(false),
+		ReqSfixed64: proto.Int64(0),
+		ReqDouble:   proto.Float64(1.23),
+		ReqString:   proto.String("hello"),
+		ReqEnum:     pb2.Enum_ONE.Enum(),
+		ReqNested:   &pb2.Nested{},
+	}
+	commonCheck(t, cfg, nil, m)
+
+	m.ReqString = nil
+	_, err := cfg.MarshalToString(m)
+	assert.Contains(t, err.Error(), "required field pb2.Requireds.req_string not set")
+	jsn := commonCheckMarshalEqual(t, cfgPartial, &protojson.MarshalOptions{AllowPartial: true}, m)
+	err = cfg.UnmarshalFromString(jsn, &pb2.Requireds{})
+	assert.Contains(t, err.Error(), "required field pb2.Requireds.req_string not set")
+	err = cfgPartial.UnmarshalFromString(jsn, &pb2.Requireds{})
+	assert.Nil(t, err)
+
+	err = cfg.UnmarshalFromString(`{"reqBool":true}`, &pb2.Requireds{})
+	assert.Contains(t, err.Error(), "required field pb2.Requireds.req_sfixed64 not set")
+	err = cfgPartial.UnmarshalFromString(`{"reqBool":true}`, &pb2.Requireds{})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		m   proto.Message
+		jsn string
+	}{
+		{&pb2.IndirectRequired{OptNested: &pb2.NestedWithRequired{}}, `{"optNested":{}}`},
+		{&pb2.IndirectRequired{RptNested: []*pb2.NestedWithRequired{{ReqString: proto.String("a")}, {}}}, `{"rptNested":[{"reqString":"a"},{}]}`},
+		{&pb2.IndirectRequired{StrToNested: map[string]*pb2.NestedWithRequired{"missing": {}}}, `{"strToNested":{"missing":{}}}`},
+		{&pb2.IndirectRequired{Union: &pb2.IndirectRequired_OneofNested{OneofNested: &pb2.NestedWithRequired{}}}, `{"oneofNested":{}}`},
+	}
+	for _, c := range cases {
+		_, err = cfg.MarshalToString(c.m)
+		assert.Contains(t, err.Error(), "required field pb2.NestedWithRequired.req_string not set")
+		err = cfg.UnmarshalFromString(c.jsn, &pb2.IndirectRequired{})
+		assert.Contains(t, err.Error(), "required field pb2.NestedWithRequired.req_string not set")
+
+		jsn, err := cfgPartial.MarshalToString(c.m)
+		assert.Nil(t, err)
+		assert.Equal(t, c.jsn, jsn)
+		err = cfgPartial.UnmarshalFromString(c.jsn, &pb2.IndirectRequired{})
+		assert.Nil(t, err)
+	}
+
+	// inside Any
+	a, err := anypb.New(&pb2.PartialRequired{OptString: proto.String("embedded inside Any")})
+	assert.NotNil(t, err) // proto.Marshal checks it too
+	a, err = anypb.New(&pb2.PartialRequired{ReqString: proto.String("x")})
+	assert.Nil(t, err)
+	commonCheck(t, cfg, nil, a)
+	v, err := proto.MarshalOptions{AllowPartial: true}.Marshal(&pb2.PartialRequired{OptString: proto.String("embedded inside Any")})
+	assert.Nil(t, err)
+	a = &anypb.Any{TypeUrl: "pb2.PartialRequired", Value: v}
+	_, err = cfg.MarshalToString(a)
+	assert.Contains(t, err.Error(), "required field pb2.PartialRequired.req_string not set")
+	jsn, err = cfgPartial.MarshalToString(a)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"@type":"pb2.PartialRequired","optString":"embedded inside Any"}`, jsn)
+	err = cfg.UnmarshalFromString(jsn, &anypb.Any{})
+	assert.Contains(t, err.Error(), "required field pb2.PartialRequired.req_string not set")
+	err = cfgPartial.UnmarshalFromString(jsn, &anypb.Any{})
+	assert.Nil(t, err)
+}
+
+func TestExtensionFields(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	m := &pb2.Extensions{
+		OptString: proto.String("str"),
+	}
+	commonCheck(t, cfg, nil, m)
+
+	proto.SetExtension(m, pb2.E_OptExtBool, true)
+	proto.SetExtension(m, pb2.E_OptExtString, "extStr")
+	proto.SetExtension(m, pb2.E_OptExtEnum, pb2.Enum_TEN)
+	proto.SetExtension(m, pb2.E_OptExtNested, &pb2.Nested{OptString: proto.String("nestedStr")})
+	proto.SetExtension(m, pb2.E_RptExtFixed32, []uint32{42, 47})
+	proto.SetExtension(m, pb2.E_ExtensionsContainer_RptExtString, []string{"a", "b"})
+	jsn := commonCheck(t, cfg, nil, m)
+	assert.Contains(t, jsn, `"[pb2.opt_ext_enum]":"TEN"`)
+	assert.Contains(t, jsn, `"[pb2.ExtensionsContainer.rpt_ext_string]":["a","b"]`)
+
+	// only extensions
+	m = &pb2.Extensions{}
+	proto.SetExtension(m, pb2.E_OptExtString, "extStr")
+	commonCheck(t, cfg, nil, m)
+
+	// null is ignored
+	m = &pb2.Extensions{}
+	err := cfg.UnmarshalFromString(`{"[pb2.opt_ext_string]":null,"optBool":true}`, m)
+	assert.Nil(t, err)
+	assert.False(t, proto.HasExtension(m, pb2.E_OptExtString))
+	assert.True(t, m.GetOptBool())
+
+	err = cfg.UnmarshalFromString(`{"[pb2.invalid_ext]":"x"}`, m)
+	assert.Contains(t, err.Error(), `unknown field "[pb2.invalid_ext]"`)
+	err = cfg.UnmarshalFromString(`{"[pb2.MessageSetExtension.message_set_extension]":{}}`, m)
+	assert.Contains(t, err.Error(), `message pb2.Extensions cannot be extended by pb2.MessageSetExtension.message_set_extension`)
+	err = cfg.UnmarshalFromString(`{"[pb2.opt_ext_bool]":"x"}`, m)
+	assert.NotNil(t, err)
+
+	// discard unknown
+	cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	m = &pb2.Extensions{}
+	err = cfg.UnmarshalFromString(`{"[pb2.invalid_ext]":"x","[pb2.opt_ext_string]":"extStr"}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, "extStr", proto.GetExtension(m, pb2.E_OptExtString))
+
+	// custom resolver
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: new(protoregistry.Types)})
+	err = cfg.UnmarshalFromString(`{"[pb2.opt_ext_string]":"extStr"}`, m)
+	assert.Contains(t, err.Error(), `unknown field "[pb2.opt_ext_string]"`)
+}
+
+func TestDynamicMessage(t *testing.T) {
+	convert := func(src, dst proto.Message) {
+		b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(src)
+		assert.Nil(t, err)
+		assert.Nil(t, proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(b, dst))
+	}
+	toDynamic := func(m proto.Message) *dynamicpb.Message {
+		dm := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+		convert(m, dm)
+		return dm
+	}
+
+	exts := []*jsoniterpb.ProtoExtension{
+		{},
+		{EmitUnpopulated: true},
+		{UseProtoNames: true, UseEnumNumbers: true},
+		{Encode64BitAsInteger: true, SortMapKeysAsString: true},
+	}
+	f := appendFuzzFuncs(gofuzz.NewWithSeed(0))
+	for _, ext := range exts {
+		cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+		cfg.RegisterExtension(ext)
+		for i := 0; i < 20; i++ {
+			var fm testv1.All
+			f.Fuzz(&fm)
+			dm := toDynamic(&fm)
+			// the nil message of oneof from fuzz is normalized to empty one
+			m := &testv1.All{}
+			convert(dm, m)
+
+			jsnExpect, err := cfg.MarshalToString(m)
+			assert.Nil(t, err)
+			jsn, err := cfg.MarshalToString(dm)
+			assert.Nil(t, err)
+			assert.Equal(t, jsnExpect, jsn)
+
+			dm2 := dynamicpb.NewMessage(dm.Descriptor())
+			err = cfg.UnmarshalFromString(jsn, dm2)
+			assert.Nil(t, err)
+			assert.True(t, ProtoEqual(dm, dm2))
+		}
+	}
+
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	// proto2 with extension fields
+	m := &pb2.Extensions{OptString: proto.String("x")}
+	proto.SetExtension(m, pb2.E_OptExtString, "y")
+	proto.SetExtension(m, pb2.E_RptExtNested, []*pb2.Nested{{OptString: proto.String("z")}})
+	dm := toDynamic(m)
+	jsn := commonCheck(t, cfg, nil, dm)
+	assert.Equal(t, commonCheck(t, cfg, nil, m), jsn)
+
+	// required
+	dm = toDynamic(&pb2.Requireds{ReqBool: proto.Bool(true)})
+	_, err := cfg.MarshalToString(dm)
+	assert.Contains(t, err.Error(), "required field pb2.Requireds.req_sfixed64 not set")
+	err = cfg.UnmarshalFromString(`{"reqBool":true}`, dynamicpb.NewMessage(dm.Descriptor()))
+	assert.Contains(t, err.Error(), "required field pb2.Requireds.req_sfixed64 not set")
+
+	// unknown fields
+	dm = dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())
+	err = cfg.UnmarshalFromString(`{"unknown":1}`, dm)
+	assert.Contains(t, err.Error(), "found unknown field: unknown")
+	cfgDiscard := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: false}.Froze()
+	cfgDiscard.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	err = cfgDiscard.UnmarshalFromString(`{"unknown":1,"snake_case":"a","e":"JSON_ENUM_SOME","s":null}`, dm)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"e":"JSON_ENUM_SOME","snakeCase":"a"}`, commonCheck(t, cfg, nil, dm))
+
+	// well known types
+	a, err := anypb.New(&testv1.All{SnakeCase: "any"})
+	assert.Nil(t, err)
+	jsn, err = cfg.MarshalToString(&testv1.All{Wkt: &testv1.WKTs{A: a, D: durationpb.New(time.Second), I32: wrapperspb.Int32(1)}})
+	assert.Nil(t, err)
+	dm = dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor())
+	err = cfg.UnmarshalFromString(jsn, dm)
+	assert.Nil(t, err)
+	jsn2, err := cfg.MarshalToString(dm)
+	assert.Nil(t, err)
+	assert.Equal(t, jsn, jsn2)
+}
+
+func TestMarshalOptions(t *testing.T) {
+	m := &testv1.All{
+		SnakeCase: "snake",
+		E:         testv1.JsonEnum_JSON_ENUM_SOME,
+		M:         &testv1.Map{},
+		Wkt: &testv1.WKTs{
+			I32: wrapperspb.Int32(1),
+		},
+	}
+	moss := []protojson.MarshalOptions{
+		{},
+		{UseProtoNames: true},
+		{UseEnumNumbers: true},
+		{EmitUnpopulated: true},
+		{Multiline: true},
+		{Indent: "\t"},
+		{Indent: "    ", UseProtoNames: true, EmitUnpopulated: true},
+	}
+	for _, mo := range moss {
+		b, err := jsoniterpb.MarshalOptions{
+			Multiline:       mo.Multiline,
+			Indent:          mo.Indent,
+			AllowPartial:    mo.AllowPartial,
+			UseProtoNames:   mo.UseProtoNames,
+			UseEnumNumbers:  mo.UseEnumNumbers,
+			EmitUnpopulated: mo.EmitUnpopulated,
+			Resolver:        mo.Resolver,
+		}.Marshal(m)
+		assert.Nil(t, err)
+		jsnExpect, err := pMarshalToStringWithOpts(mo, m)
+		assert.Nil(t, err)
+		if mo.Multiline || mo.Indent != "" {
+			// protojson adds random spaces after colons unless detrand is disabled, so only compare the compacted
+			indent := mo.Indent
+			if indent == "" {
+				indent = "  "
+			}
+			assert.Contains(t, string(b), "\n"+indent+`"`)
+			var out bytes.Buffer
+			assert.Nil(t, json.Compact(&out, b))
+			b = out.Bytes()
+		}
+		assert.Equal(t, jsnExpect, string(b))
+	}
+
+	_, err := jsoniterpb.MarshalOptions{Indent: "a"}.Marshal(m)
+	assert.Contains(t, err.Error(), "indent may only be composed of space or tab characters")
+
+	// nil message
+	b, err := jsoniterpb.Marshal(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(b))
+	b, err = jsoniterpb.Marshal((*testv1.All)(nil))
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(b))
+	assert.Equal(t, "<nil>", jsoniterpb.Format(nil))
+	assert.Equal(t, "{\n  \"snakeCase\": \"snake\"\n}", jsoniterpb.Format(&testv1.All{SnakeCase: "snake"}))
+
+	// reset before unmarshaling
+	m2 := &testv1.All{LowerCamelCase: "lower"}
+	err = jsoniterpb.Unmarshal([]byte(`{"snakeCase":"snake"}`), m2)
+	assert.Nil(t, err)
+	assert.True(t, ProtoEqual(&testv1.All{SnakeCase: "snake"}, m2))
+
+	err = jsoniterpb.Unmarshal([]byte(`{"unknown":"snake"}`), m2)
+	assert.NotNil(t, err)
+	err = jsoniterpb.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(`{"unknown":"snake"}`), m2)
+	assert.Nil(t, err)
+
+	// resolver
+	a, err := anypb.New(&testv1.All{SnakeCase: "snake"})
+	assert.Nil(t, err)
+	b, err = jsoniterpb.Marshal(a)
+	assert.Nil(t, err)
+	_, err = jsoniterpb.MarshalOptions{Resolver: &protoregistry.Types{}}.Marshal(a)
+	assert.Contains(t, err.Error(), "unable to resolve")
+	err = jsoniterpb.UnmarshalOptions{Resolver: &protoregistry.Types{}}.Unmarshal(b, &anypb.Any{})
+	assert.Contains(t, err.Error(), "unable to resolve")
+	a2 := &anypb.Any{}
+	err = jsoniterpb.Unmarshal(b, a2)
+	assert.Nil(t, err)
+	assert.True(t, ProtoEqual(a, a2))
+	// the resolver which is not comparable
+	type resolver struct {
+		*protoregistry.Types
+		_ []int
+	}
+	err = jsoniterpb.UnmarshalOptions{Resolver: resolver{Types: &protoregistry.Types{}}}.Unmarshal(b, &anypb.Any{})
+	assert.Contains(t, err.Error(), "unable to resolve")
+}
+
+func TestIndention(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true, IndentionStep: 4}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	st, err := structpb.NewStruct(map[string]interface{}{
+		"a": map[string]interface{}{"b": []interface{}{1, "c", map[string]interface{}{}}},
+		"d": []interface{}{},
+	})
+	assert.Nil(t, err)
+	a, err := anypb.New(&testv1.Map{
+		Str: map[int64]string{1: "a", 2: "b"},
+		Msg: map[int32]*testv1.Nested{1: {}, 2: {N: &testv1.Nested_NestedMessage{}}},
+	})
+	assert.Nil(t, err)
+	m := &testv1.All{
+		SnakeCase: "snake",
+		M: &testv1.Map{
+			En: map[string]testv1.JsonEnum{"a": testv1.JsonEnum_JSON_ENUM_SOME},
+			An: map[uint64]*anypb.Any{1: a},
+		},
+		Wkt: &testv1.WKTs{
+			A:  a,
+			St: st,
+			Lv: st.Fields["a"].GetStructValue().Fields["b"].GetListValue(),
+		},
+		S: &testv1.Singular{},
+	}
+
+	jsn, err := pMarshalToString(m)
+	assert.Nil(t, err)
+	for v, jsnExpect := range map[interface{}]string{
+		m:                               jsn,
+		&testv1.All{}:                   "{}",
+		&[]*testv1.All{m, {}}:           "[" + jsn + ",{}]",
+		&map[string]*testv1.All{"m": m}: `{"m":` + jsn + "}",
+	} {
+		var out bytes.Buffer
+		assert.Nil(t, json.Indent(&out, []byte(jsnExpect), "", "    "))
+		b, err := cfg.Marshal(v)
+		assert.Nil(t, err)
+		assert.Equal(t, out.String(), string(b))
+		// the stream is flushed to the writer
+		var w bytes.Buffer
+		assert.Nil(t, cfg.NewEncoder(&w).Encode(v))
+		assert.Equal(t, out.String()+"\n", w.String())
+	}
+
+	// inside the objects and the arrays written by jsoniter
+	type wrapper struct {
+		At  time.Time              `json:"at"`
+		Ms  []*testv1.All          `json:"ms"`
+		Any []interface{}          `json:"any"`
+		Map map[string]interface{} `json:"map"`
+	}
+	v := &wrapper{Ms: []*testv1.All{m}, Any: []interface{}{m}, Map: map[string]interface{}{"m": m}}
+	var out bytes.Buffer
+	assert.Nil(t, json.Indent(&out, []byte(`{"at":"0001-01-01T00:00:00Z","ms":[`+jsn+`],"any":[`+jsn+`],"map":{"m":`+jsn+`}}`), "", "    "))
+	b, err := cfg.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, out.String(), string(b))
+	// the output of the json.Marshaler is flushed to the writer before the messages
+	var w bytes.Buffer
+	assert.Nil(t, cfg.NewEncoder(&w).Encode(v))
+	assert.Equal(t, out.String()+"\n", w.String())
+
+	// the same as protojson Multiline, which is json.Indent if detrand disabled
+	for _, indent := range []string{"  ", "\t", " \t"} {
+		var out bytes.Buffer
+		assert.Nil(t, json.Indent(&out, []byte(jsn), "", indent))
+		b, err := jsoniterpb.MarshalOptions{Indent: indent}.Marshal(m)
+		assert.Nil(t, err)
+		assert.Equal(t, out.String(), string(b))
+	}
+}
+
+func TestDuplicateFields(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{DuplicateFields: jsoniterpb.DuplicateFieldsDisallow})
+
+	for _, jsn := range []string{
+		`{"snakeCase":"a","snakeCase":"b"}`,
+		`{"snakeCase":"a","snake_case":"b"}`,
+		`{"snakeCase":"a","SnakeCase":"b"}`,
+		`{"s":{"i32":1,"i32":2}}`,
+		`{"r":{"i32":[1]},"r":{"i32":[2]}}`,
+		`{"snakeCase":null,"snakeCase":"b"}`,
+	} {
+		err := cfg.UnmarshalFromString(jsn, &testv1.All{})
+		assert.Contains(t, err.Error(), "duplicate field", jsn)
+		err = cfg.UnmarshalFromString(jsn, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor()))
+		assert.Contains(t, err.Error(), "duplicate field", jsn)
+	}
+	// the field is named in the same way as the output
+	err := cfg.UnmarshalFromString(`{"snakeCase":"a","snake_case":"b"}`, &testv1.All{})
+	assert.Contains(t, err.Error(), `duplicate field "snakeCase"`)
+	err = cfg.UnmarshalFromString(`{"[pb2.opt_ext_string]":"a","[pb2.opt_ext_string]":"b"}`, &pb2.Extensions{})
+	assert.Contains(t, err.Error(), `duplicate field "[pb2.opt_ext_string]"`)
+	err = cfg.UnmarshalFromString(`{"optString":"a","[pb2.opt_ext_string]":"b"}`, &pb2.Extensions{})
+	assert.Nil(t, err)
+
+	// the last one wins by default
+	m := &testv1.All{}
+	err = jsoniter.Config{SortMapKeys: true}.Froze().UnmarshalFromString(`{"snakeCase":"a","snake_case":"b"}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", m.SnakeCase)
+
+	cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	m = &testv1.All{}
+	err = cfg.UnmarshalFromString(`{"snakeCase":"a","snake_case":"b","unknown":1,"unknown":2}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", m.SnakeCase)
+
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	m = &testv1.All{}
+	err = cfg.UnmarshalFromString(`{"snakeCase":"a","snake_case":"b"}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, "b", m.SnakeCase)
+
+	cfg = jsoniter.Config{SortMapKeys: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{DuplicateFields: jsoniterpb.DuplicateFieldsDisallow})
+	err = cfg.UnmarshalFromString(`{"unknown":1,"unknown":2}`, &testv1.All{})
+	assert.Nil(t, err)
+	err = cfg.UnmarshalFromString(`{"snakeCase":"a","snake_case":"b"}`, &testv1.All{})
+	assert.Contains(t, err.Error(), `duplicate field "snakeCase"`)
+
+	// the same as protojson even if DiscardUnknown
+	err = jsoniterpb.UnmarshalOptions{DiscardUnknown: true}.Unmarshal([]byte(`{"snakeCase":"a","snake_case":"b"}`), &testv1.All{})
+	assert.Contains(t, err.Error(), `duplicate field "snakeCase"`)
+}
+
+func TestDuplicateMapKeys(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{DuplicateFields: jsoniterpb.DuplicateFieldsDisallow})
+
+	for jsn, key := range map[string]string{
+		`{"m":{"str":{"0":"a","0":"b"}}}`:           "0",
+		`{"m":{"str":{"1":"a","01":"b"}}}`:          "01",
+		`{"m":{"str":{"-1":"a","-01":"b"}}}`:        "-01",
+		`{"m":{"en":{"a":"JSON_ENUM_SOME","a":0}}}`: "a",
+		`{"m":{"by":{"true":"","true":""}}}`:        "true",
+		`{"m":{"bo":{"2":true,"02":false}}}`:        "02",
+		`{"m":{"msg":{"1":{},"1":{}}}}`:             "1",
+	} {
+		err := cfg.UnmarshalFromString(jsn, &testv1.All{})
+		assert.Contains(t, err.Error(), fmt.Sprintf("duplicate map key %q", key), jsn)
+		err = cfg.UnmarshalFromString(jsn, dynamicpb.NewMessage((&testv1.All{}).ProtoReflect().Descriptor()))
+		assert.Contains(t, err.Error(), fmt.Sprintf("duplicate map key %q", key), jsn)
+	}
+
+	// the existing keys are not duplicate
+	m := &testv1.All{M: &testv1.Map{Str: map[int64]string{1: "a"}}}
+	err := cfg.UnmarshalFromString(`{"m":{"str":{"1":"b","2":"c"}}}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]string{1: "b", 2: "c"}, m.M.Str)
+
+	// the last one wins by default
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+	m = &testv1.All{}
+	err = cfg.UnmarshalFromString(`{"m":{"str":{"1":"a","1":"b"}}}`, m)
+	assert.Nil(t, err)
+	assert.Equal(t, map[int64]string{1: "b"}, m.M.Str)
+
+	// struct is not a map field
+	cfg = jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{DuplicateFields: jsoniterpb.DuplicateFieldsDisallow})
+	err = cfg.UnmarshalFromString(`{"m":{"str":{"1":"a","1":"b"}}}`, &map[string]map[string]map[int]string{})
+	assert.Nil(t, err)
+}
+
+func TestErrors(t *testing.T) {
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{})
+
+	asError := func(err error) *jsoniterpb.Error {
+		var perr *jsoniterpb.Error
+		assert.True(t, errors.As(err, &perr), err)
+		return perr
+	}
+
+	for _, c := range []struct {
+		m         proto.Message
+		jsn       string
+		path      string
+		fieldName protoreflect.FullName
+		offset    int
+		cause     string
+	}{
+		{&testv1.Repeated{}, `{"e":["JSON_ENUM_SOME", "X"]}`, "e[1]", "test.v1.Repeated.e", 24, "error decode from string"},
+		{&testv1.Repeated{}, `{"msg":[{},{"id":[]}]}`, "msg[1].id", "test.v1.Message.id", 18, ""},
+		{&testv1.Map{}, `{"en":{"a":"X"}}`, `en["a"]`, "test.v1.Map.en", 11, "error decode from string"},
+		{&testv1.Map{}, `{"msg":{"1":{"n":{"e":"X"}}}}`, `msg["1"].n.e`, "test.v1.Nested.NestedMessage.e", 22, "error decode from string"},
+		{&testv1.OneOf{}, `{"extra":"a","e":"X"}`, "e", "test.v1.OneOf.e", 17, "error decode from string"},
+		{&testv1.OneOfWKT{}, `{"d":"1"}`, "d", "test.v1.OneOfWKT.d", 5, "invalid google.protobuf.Duration value"},
+		{&testv1.Singular{}, `{"by":"!!"}`, "by", "test.v1.Singular.by", 6, "illegal base64 data"},
+		{&testv1.Singular{}, "{\"s\":\"\xff\"}", "s", "test.v1.Singular.s", 5, "invalid UTF-8"},
+		{&testv1.Singular{}, `{"i32": "1.5"}`, "i32", "test.v1.Singular.i32", 8, "found frac"},
+		{&testv1.Singular{}, `{"f32":"x"}`, "f32", "test.v1.Singular.f32", 7, ""},
+		{&testv1.Singular{}, `{"bl":[]}`, "bl", "test.v1.Singular.bl", 6, "not number or string"},
+		{&testv1.RepeatedWKTs{}, `{"st":[{"a":[1,{"b":{}}]},{"a":[1,{"b":true,"b":1}]}]}`, `st[1]["a"][1]`, "test.v1.RepeatedWKTs.st", 48, `duplicate "b" field`},
+		{&testv1.RepeatedWKTs{}, `{"a":[{"@type":"type.googleapis.com/google.protobuf.Duration","value":"x"}]}`, "a[0].value", "test.v1.RepeatedWKTs.a", 70, "invalid google.protobuf.Duration value"},
+		{&testv1.RepeatedWKTs{}, `{"a":[{},{"@type":"type.googleapis.com/test.v1.Singular","s":"","e":"X"}]}`, "a[1].e", "test.v1.Singular.e", 68, "error decode from string"},
+		{&testv1.RepeatedWKTs{}, `{"a":[{"id":1}]}`, "a[0]", "test.v1.RepeatedWKTs.a", 6, `missing "@type" field`},
+		{&testv1.OneOf{}, `{"sTr":"a","i32":1}`, "", "", 17, "oneof test.v1.OneOf.one_of is already set"},
+		{&pb2.Extensions{}, `{"[pb2.opt_ext_nested]":{"optString":{}}}`, "[pb2.opt_ext_nested].optString", "pb2.Nested.opt_string", 38, ""},
+	} {
+		for _, m := range []proto.Message{c.m, dynamicpb.NewMessage(c.m.ProtoReflect().Descriptor())} {
+			err := cfg.UnmarshalFromString(c.jsn, m)
+			perr := asError(err)
+			if perr == nil {
+				continue
+			}
+			assert.Equal(t, c.path, perr.Path, c.jsn)
+			assert.Equal(t, c.fieldName, perr.FieldName, c.jsn)
+			assert.Contains(t, perr.Err.Error(), c.cause, c.jsn)
+			assert.Equal(t, perr.Err, errors.Unwrap(err))
+			if _, ok := m.(*dynamicpb.Message); !ok {
+				assert.Equal(t, c.offset, perr.Offset, c.jsn)
+			}
+		}
+	}
+
+	// the path of non-proto types
+	var mp map[string][]*testv1.Singular
+	perr := asError(cfg.UnmarshalFromString(`{"k":[{},{"e":"X"}]}`, &mp))
+	assert.Equal(t, `["k"][1].e`, perr.Path)
+	assert.Equal(t, protoreflect.FullName("test.v1.Singular.e"), perr.FieldName)
+	assert.Equal(t, 14, perr.Offset)
+
+	// line and column
+	err := jsoniterpb.Unmarshal([]byte("{\n  \"i32\": 1,\n  \"msg\": {\"id\": \"a\"},\n  \"e\": \"X\"\n}"), &testv1.Singular{})
+	perr = asError(err)
+	assert.Equal(t, "e", perr.Path)
+	assert.Equal(t, 4, perr.Line)
+	assert.Equal(t, 8, perr.Column)
+
+	// marshal
+	for _, c := range []struct {
+		m    proto.Message
+		path string
+	}{
+		{&testv1.Repeated{S: []string{"a", "\xff"}}, "s[1]"},
+		{&testv1.Map{Str: map[int64]string{1: "a", 3: "\xff"}}, `str["3"]`},
+		{&testv1.OneOf{OneOf: &testv1.OneOf_STr{STr: "\xff"}}, "sTr"},
+		{&testv1.RepeatedWKTs{St: []*structpb.Struct{{Fields: map[string]*structpb.Value{"k": {}}}}}, `st[0]["k"]`},
+	} {
+		dm := dynamicpb.NewMessage(c.m.ProtoReflect().Descriptor())
+		proto.Merge(dm, c.m)
+		for _, m := range []proto.Message{c.m, dm} {
+			_, err := cfg.Marshal(m)
+			perr := asError(err)
+			if perr == nil {
+				continue
+			}
+			assert.Equal(t, c.path, perr.Path)
+			assert.Equal(t, -1, perr.Offset)
+		}
+	}
+
+	// the plain Go types are left to jsoniter
+	_, err = cfg.Marshal([]string{"a", "\xff"})
+	assert.Contains(t, err.Error(), "invalid UTF-8")
+	assert.False(t, errors.As(err, &perr))
+	_, err = cfg.Marshal(map[string][]*testv1.Repeated{"k": {{}, {S: []string{"\xff"}}}})
+	perr = asError(err)
+	assert.Equal(t, `["k"][1].s[0]`, perr.Path)
+}
+
+func TestProtojsonErrors(t *testing.T) {
+	for _, c := range []struct {
+		m   proto.Message
+		jsn string
+	}{
+		{&testv1.Singular{}, "{\n  \"e\": \"X\"\n}"},
+		{&testv1.Singular{}, "{\"s\":\"\xff\"}"},
+		{&testv1.Singular{}, `{"sTr":"a","s_tr":"b"}`},
+		{&testv1.Singular{}, `{"msg":true}`},
+		{&testv1.Singular{}, `{"unknown":1}`},
+		{&testv1.Repeated{}, `{"msg":[{},true]}`},
+		{&testv1.RepeatedWKTs{}, `{"a":[{"@type":"type.googleapis.com/test.v1.Singular","e":"X"}]}`},
+		{&testv1.Singular{}, `{} {}`},
+	} {
+		want := protojson.Unmarshal([]byte(c.jsn), c.m.ProtoReflect().New().Interface())
+		assert.NotNil(t, want, c.jsn)
+		for _, m := range []proto.Message{c.m, dynamicpb.NewMessage(c.m.ProtoReflect().Descriptor())} {
+			err := jsoniterpb.UnmarshalOptions{ProtojsonErrors: true}.Unmarshal([]byte(c.jsn), m)
+			assert.EqualError(t, err, want.Error(), c.jsn)
+		}
+	}
+
+	// the path and the position are kept
+	err := jsoniterpb.UnmarshalOptions{ProtojsonErrors: true}.Unmarshal([]byte("{\"msg\":[{},\n true]}"), &testv1.Repeated{})
+	assert.Contains(t, err.Error(), `(line 2:2): unexpected token true`)
+	var perr *jsoniterpb.Error
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, `msg[1]`, perr.Path)
+	assert.Equal(t, 2, perr.Line)
+
+	// the path and the position are moved to the failure protojson finds, e.g. 42 is accepted for the string by fuzzy decode
+	err = jsoniterpb.UnmarshalOptions{ProtojsonErrors: true}.Unmarshal([]byte(`{"s":42,"e":"X"}`), &testv1.Singular{})
+	assert.Contains(t, err.Error(), `(line 1:6): invalid value for string type: 42`)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, "s", perr.Path)
+	assert.Equal(t, protoreflect.FullName("test.v1.Singular.s"), perr.FieldName)
+	assert.Equal(t, 5, perr.Offset)
+	assert.Equal(t, 6, perr.Column)
+
+	// the positions are of the whole input even if the message is nested in other types
+	cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true}.Froze()
+	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{ProtojsonErrors: true})
+	var mp map[string]*testv1.Repeated
+	err = cfg.UnmarshalFromString("{\"k\":\n {\"msg\": [{}, {\"unknown\": 1}]}}", &mp)
+	assert.Contains(t, err.Error(), `(line 2:16): unknown field "unknown"`)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, `["k"].msg[1].unknown`, perr.Path)
+	assert.Equal(t, 21, perr.Offset)
 }
 
 func TestNilValues(t *testing.T) {
@@ -0,0 +1,151 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// https://developers.google.com/protocol-buffers/docs/proto3#json
+// extension fields are represented as "[full.name.of.extension]": value
+// e.g. {"optString":"x","[pb2.opt_ext_string]":"y"}
+// The generated messages with extension ranges are decoded via protoreflect, see isExtendableMessageType,
+// and the extension fields are appended after the struct fields when encoding.
+
+// protoMessageDescriptor returns the message descriptor if typ is the struct type of a generated message
+// which is not handled by ProtoCodecs
+func protoMessageDescriptor(typ reflect2.Type) protoreflect.MessageDescriptor {
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	if !reflect2.PtrTo(typ).Implements(protoMessageType) {
+		return nil
+	}
+	if _, ok := ProtoCodecs[typ]; ok {
+		return nil
+	}
+	if !isGeneratedMessageType(typ) {
+		return nil
+	}
+	return typ.New().(proto.Message).ProtoReflect().Descriptor()
+}
+
+// isExtendableMessageType reports whether typ is the struct type of a generated message with extension ranges
+func isExtendableMessageType(typ reflect2.Type) bool {
+	md := protoMessageDescriptor(typ)
+	return md != nil && md.ExtensionRanges().Len() > 0
+}
+
+func isExtensionFieldName(name string) bool {
+	return len(name) > 2 && strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]")
+}
+
+// findExtensionField resolves the extension field named "[full.name]" of md by the resolver of the call,
+// nil is returned if it is unknown and unknown fields are allowed
+func (e *ProtoExtension) findExtensionField(attachment interface{}, md protoreflect.MessageDescriptor, name string, disallowUnknownFields bool) (protoreflect.ExtensionType, error) {
+	xt, err := e.resolverOf(attachment).FindExtensionByName(protoreflect.FullName(name[1 : len(name)-1]))
+	if err != nil && err != protoregistry.NotFound {
+		return nil, fmt.Errorf("unable to resolve %q: %q", name, err)
+	}
+	if xt == nil {
+		if disallowUnknownFields {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		return nil, nil
+	}
+	xd := xt.TypeDescriptor()
+	if !md.ExtensionRanges().Has(xd.Number()) || xd.ContainingMessage().FullName() != md.FullName() {
+		return nil, fmt.Errorf("message %v cannot be extended by %v", md.FullName(), xd.FullName())
+	}
+	return xt, nil
+}
+
+func (e *ProtoExtension) decorateEncoderForExtensionFields(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	md := protoMessageDescriptor(typ)
+	if md == nil || md.ExtensionRanges().Len() <= 0 {
+		return nil
+	}
+	return &protoExtensionFieldsEncoder{
+		valueType:    typ,
+		valueEncoder: encoder,
+	}
+}
+
+type protoExtensionFieldsEncoder struct {
+	valueType    reflect2.Type
+	valueEncoder jsoniter.ValEncoder
+}
+
+func (enc *protoExtensionFieldsEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if hasStreamError(stream) {
+		return
+	}
+	m := enc.valueType.PackEFace(ptr).(proto.Message).ProtoReflect()
+
+	var xds []protoreflect.ExtensionTypeDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsExtension() {
+			xds = append(xds, fd.(protoreflect.ExtensionTypeDescriptor))
+		}
+		return true
+	})
+	if len(xds) <= 0 {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	// same order as protojson
+	sort.Slice(xds, func(i, j int) bool {
+		return xds[i].FullName() < xds[j].FullName()
+	})
+
+	subStream := stream.API().BorrowStream(nil)
+	subStream.Attachment = stream.Attachment
+	defer stream.API().ReturnStream(subStream)
+	enc.valueEncoder.Encode(ptr, subStream)
+	if subStream.Error != nil && subStream.Error != io.EOF {
+		transferStreamError(stream, subStream)
+		return
+	}
+
+	subIter := stream.API().BorrowIterator(subStream.Buffer())
+	defer stream.API().ReturnIterator(subIter)
+
+	more := false
+	stream.WriteObjectStart()
+	subIter.ReadObjectCB(func(iter *jsoniter.Iterator, field string) bool {
+		if more {
+			stream.WriteMore()
+		}
+		more = true
+		stream.WriteObjectField(field)
+		stream.Write(iter.SkipAndReturnBytes())
+		return true
+	})
+	for _, xd := range xds {
+		if more {
+			stream.WriteMore()
+		}
+		more = true
+		stream.WriteObjectField("[" + string(xd.FullName()) + "]")
+		stream.WriteVal(xd.Type().InterfaceOf(m.Get(xd)))
+		if e := streamError(stream); e != nil {
+			e.prependField("["+string(xd.FullName())+"]", xd)
+			setStreamError(stream, e)
+			return
+		}
+	}
+	stream.WriteObjectEnd()
+}
+
+func (enc *protoExtensionFieldsEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
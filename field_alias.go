@@ -0,0 +1,93 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The aliases of a field are only accepted for the input, like the proto name of a field named by the json name,
+// so a field can be renamed without breaking the clients sending the old name, and the new name is the only one emitted.
+// An alias must not be a name of another field or one of its aliases, otherwise the message is rejected when it is decoded.
+
+// fieldAliases returns the aliases of fd by FieldAliases and FieldAliasOption
+func (e *ProtoExtension) fieldAliases(fd protoreflect.FieldDescriptor) []string {
+	if fd == nil || fd.IsExtension() {
+		return nil
+	}
+	aliases := e.FieldAliases[fd.FullName()]
+	if e.FieldAliasOption == nil {
+		return aliases
+	}
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, e.FieldAliasOption) {
+		return aliases
+	}
+	// the slice of FieldAliases is never appended in place
+	switch v := proto.GetExtension(opts, e.FieldAliasOption).(type) {
+	case string:
+		aliases = append(aliases[:len(aliases):len(aliases)], v)
+	case []string:
+		aliases = append(aliases[:len(aliases):len(aliases)], v...)
+	case protoreflect.List: // the repeated option of dynamicpb
+		aliases = aliases[:len(aliases):len(aliases)]
+		for i := 0; i < v.Len(); i++ {
+			aliases = append(aliases, v.Get(i).String())
+		}
+	}
+	return aliases
+}
+
+// hasFieldAliases reports whether the fields may have aliases
+func (e *ProtoExtension) hasFieldAliases() bool {
+	return len(e.FieldAliases) > 0 || e.FieldAliasOption != nil
+}
+
+// checkFieldAliases returns an error if an alias of a field of md is the custom name, the json name, the proto name or an alias of another field
+func (e *ProtoExtension) checkFieldAliases(md protoreflect.MessageDescriptor) error {
+	owners := map[string]protoreflect.FieldDescriptor{}
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		for _, name := range []string{e.customFieldName(fd), fd.JSONName(), fd.TextName()} {
+			if _, ok := owners[name]; !ok && name != "" {
+				owners[name] = fd
+			}
+		}
+	}
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		for _, alias := range e.fieldAliases(fd) {
+			if owner, ok := owners[alias]; ok && owner != fd {
+				return fmt.Errorf("jsoniterpb: alias %q of %s conflicts with %s", alias, fd.FullName(), owner.FullName())
+			}
+			owners[alias] = fd
+		}
+	}
+	return nil
+}
+
+func (e *ProtoExtension) decorateDecoderForFieldAliases(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if !e.hasFieldAliases() {
+		return nil
+	}
+	md := protoMessageDescriptor(typ)
+	// the messages decoded via protoreflect are checked by protoReflectMessageDecoder
+	if md == nil || md.ExtensionRanges().Len() > 0 {
+		return nil
+	}
+	err := e.fieldsByNameOf(md).err
+	if err == nil {
+		return nil
+	}
+	return &funcDecoder{
+		fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+			reportIterError(iter, iterOffset(iter), err)
+		},
+	}
+}
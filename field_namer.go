@@ -0,0 +1,67 @@
+package jsoniterpb
+
+import (
+	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldNamer takes the place of the json name or the proto name for the output,
+// and the input is accepted by all of the three names, e.g. "FooBar", "fooBar" and "foo_bar".
+// The name is also used for the path of Error and FuzzyDecodeReport.
+
+// customFieldName returns the name of fd by the name option or FieldNamer, empty if there is none
+func (e *ProtoExtension) customFieldName(fd protoreflect.FieldDescriptor) string {
+	if fd == nil || fd.IsExtension() {
+		return ""
+	}
+	if name := e.fieldOptionsOf(fd).GetName(); name != "" {
+		return name
+	}
+	if e.FieldNamer == nil {
+		return ""
+	}
+	return e.FieldNamer(fd)
+}
+
+// fieldName returns the output name of fd
+func (e *ProtoExtension) fieldName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsExtension() {
+		return "[" + string(fd.FullName()) + "]"
+	}
+	if name := e.customFieldName(fd); name != "" {
+		return name
+	}
+	if e.UseProtoNames {
+		return fd.TextName()
+	}
+	return fd.JSONName()
+}
+
+// applyCustomFieldNames makes the name by FieldNamer the output name of binding and the first of the input names,
+// and the aliases of the field are appended to the input names
+func (e *ProtoExtension) applyCustomFieldNames(binding *jsoniter.Binding, fd protoreflect.FieldDescriptor) {
+	if name := e.customFieldName(fd); name != "" {
+		fromNames := []string{name}
+		for _, n := range binding.FromNames {
+			if n != name {
+				fromNames = append(fromNames, n)
+			}
+		}
+		binding.FromNames = fromNames
+		binding.ToNames = []string{name}
+	}
+	for _, alias := range e.fieldAliases(fd) {
+		if !containsString(binding.FromNames, alias) {
+			binding.FromNames = append(binding.FromNames, alias)
+		}
+	}
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
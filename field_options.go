@@ -0,0 +1,189 @@
+package jsoniterpb
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The fields can be annotated in .proto files with the options of jsoniterpb/options.proto, e.g.
+//
+//	import "jsoniterpb/options.proto";
+//
+//	message User {
+//	  string id = 1 [(jsoniterpb.field).name = "_id"];
+//	  string password = 2 [(jsoniterpb.field).ignore = true];
+//	  int64 balance = 3 [(jsoniterpb.field) = {int64_as_number: true, omit_empty: false}];
+//	}
+//
+// The options are specific to the fields, so they take precedence over FieldNamer and EmitPolicy.
+
+// fieldOptionsOf returns the jsoniterpb options of fd, nil if there are none,
+// they are cached per field in e, so that the descriptors are released with the config
+func (e *ProtoExtension) fieldOptionsOf(fd protoreflect.FieldDescriptor) *FieldOptions {
+	if fd == nil || fd.IsExtension() {
+		return nil
+	}
+	if v, ok := e.fieldOptions.Load(fd); ok {
+		return v.(*FieldOptions)
+	}
+	var fo *FieldOptions
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts != nil && proto.HasExtension(opts, E_Field) {
+		fo, _ = proto.GetExtension(opts, E_Field).(*FieldOptions)
+	}
+	v, _ := e.fieldOptions.LoadOrStore(fd, fo)
+	return v.(*FieldOptions)
+}
+
+// isIgnoredField reports whether fd is skipped when marshaling and unmarshaling
+func (e *ProtoExtension) isIgnoredField(fd protoreflect.FieldDescriptor) bool {
+	return e.fieldOptionsOf(fd).GetIgnore()
+}
+
+// int64AsNumber reports whether the 64-bit integers of fd, including the values of a map field, are marshaled as numbers
+func (e *ProtoExtension) int64AsNumber(fd protoreflect.FieldDescriptor) bool {
+	if e.Encode64BitAsInteger || fd == nil {
+		return false
+	}
+	kind := fd.Kind()
+	if fd.IsMap() {
+		kind = fd.MapValue().Kind()
+	}
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return e.fieldOptionsOf(fd).GetInt64AsNumber()
+	}
+	return false
+}
+
+func (e *ProtoExtension) wrapFieldEncoderForInt64AsNumber(fd protoreflect.FieldDescriptor, fieldType reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if !e.int64AsNumber(fd) {
+		return encoder
+	}
+	return &protoInt64AsNumberEncoder{
+		ext:        e,
+		fd:         fd,
+		fieldType:  fieldType,
+		ValEncoder: encoder,
+	}
+}
+
+// wrapStructFieldEncoderForInt64AsNumber is the same as wrapFieldEncoderForInt64AsNumber,
+// except that encoder is given the pointer of the struct which has field, e.g. the wrapper of a oneof member
+func (e *ProtoExtension) wrapStructFieldEncoderForInt64AsNumber(fd protoreflect.FieldDescriptor, field reflect2.StructField, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if !e.int64AsNumber(fd) {
+		return encoder
+	}
+	return &protoInt64AsNumberEncoder{
+		ext:        e,
+		fd:         fd,
+		field:      field,
+		fieldType:  field.Type(),
+		ValEncoder: encoder,
+	}
+}
+
+// protoInt64AsNumberEncoder writes the 64-bit integers of the field as numbers,
+// the keys of a map field are kept quoted, and the unset or empty values are left to the encoder of the field
+type protoInt64AsNumberEncoder struct {
+	ext *ProtoExtension
+	fd  protoreflect.FieldDescriptor
+	// field is set if ValEncoder is given the pointer of the struct
+	field     reflect2.StructField
+	fieldType reflect2.Type
+	jsoniter.ValEncoder
+
+	once        sync.Once
+	sortMapKeys bool
+}
+
+func (enc *protoInt64AsNumberEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	enc.once.Do(func() {
+		if fcfg, ok := stream.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok {
+			enc.sortMapKeys = fcfg.GetConfig().SortMapKeys
+		}
+	})
+
+	fieldPtr := ptr
+	if enc.field != nil {
+		fieldPtr = enc.field.UnsafeGet(ptr)
+	}
+	switch enc.fieldType.Kind() {
+	case reflect.Ptr:
+		// proto2 optional field
+		typ := enc.fieldType.(reflect2.PtrType)
+		if *(*unsafe.Pointer)(fieldPtr) == nil {
+			enc.ValEncoder.Encode(ptr, stream)
+			return
+		}
+		writeInt64AsNumber(typ.Elem().Kind(), *(*unsafe.Pointer)(fieldPtr), stream)
+	case reflect.Slice:
+		typ := enc.fieldType.(reflect2.SliceType)
+		n := typ.UnsafeLengthOf(fieldPtr)
+		if n == 0 {
+			enc.ValEncoder.Encode(ptr, stream)
+			return
+		}
+		stream.WriteArrayStart()
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			writeInt64AsNumber(typ.Elem().Kind(), typ.UnsafeGetIndex(fieldPtr, i), stream)
+		}
+		stream.WriteArrayEnd()
+	case reflect.Map:
+		typ := enc.fieldType.(reflect2.MapType)
+		var keys []protoreflect.MapKey
+		elems := map[interface{}]unsafe.Pointer{}
+		for iter := typ.UnsafeIterate(fieldPtr); iter.HasNext(); {
+			k, elem := iter.UnsafeNext()
+			key := protoreflect.ValueOf(typ.Key().UnsafeIndirect(k)).MapKey()
+			keys = append(keys, key)
+			elems[key.Interface()] = elem
+		}
+		if len(keys) == 0 {
+			enc.ValEncoder.Encode(ptr, stream)
+			return
+		}
+		if enc.sortMapKeys {
+			sortMapKeys(keys, enc.fd.MapKey().Kind(), enc.ext.SortMapKeysAsString)
+		}
+		stream.WriteObjectStart()
+		for i, key := range keys {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(key.String())
+			writeInt64AsNumber(typ.Elem().Kind(), elems[key.Interface()], stream)
+		}
+		stream.WriteObjectEnd()
+	default:
+		writeInt64AsNumber(enc.fieldType.Kind(), fieldPtr, stream)
+	}
+}
+
+func (enc *protoInt64AsNumberEncoder) IsEmbeddedPtrNil(ptr unsafe.Pointer) bool {
+	isEmbeddedPtrNil, converted := enc.ValEncoder.(jsoniter.IsEmbeddedPtrNil)
+	if !converted {
+		return false
+	}
+	return isEmbeddedPtrNil.IsEmbeddedPtrNil(ptr)
+}
+
+func writeInt64AsNumber(kind reflect.Kind, ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if kind == reflect.Uint64 {
+		stream.WriteUint64(*(*uint64)(ptr))
+		return
+	}
+	stream.WriteInt64(*(*int64)(ptr))
+}
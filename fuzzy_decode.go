@@ -0,0 +1,77 @@
+package jsoniterpb
+
+import (
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FuzzyDecodeOptions is the set of the fuzzy decode rules, which accept the input rejected by protojson
+type FuzzyDecodeOptions uint
+
+const (
+	// FuzzyDecodeNumericString accepts numbers in strings for 32-bit integers and floats, e.g. "1.5",
+	// 64-bit integers are always accepted in strings, which is the same as protojson, and the empty string is 0 for integers except with Strict
+	FuzzyDecodeNumericString FuzzyDecodeOptions = 1 << iota
+	// FuzzyDecodeNumberToString accepts numbers for strings, e.g. 42 is "42"
+	FuzzyDecodeNumberToString
+	// FuzzyDecodeBoolToNumber accepts bools for numbers, e.g. true is 1
+	FuzzyDecodeBoolToNumber
+	// FuzzyDecodeNumberToBool accepts numbers and numeric strings for bools, e.g. 1 and "0"
+	FuzzyDecodeNumberToBool
+	// FuzzyDecodeBoolString accepts "true" and "false" for bools and numbers, e.g. "true" is 1
+	FuzzyDecodeBoolString
+	// FuzzyDecodeNullToZero accepts null for scalars and the elements of repeated and map fields, which is the zero value
+	FuzzyDecodeNullToZero
+	// FuzzyDecodeEnumNumericString accepts numbers in strings for enums, e.g. "1"
+	FuzzyDecodeEnumNumericString
+	// FuzzyDecodeExponentInteger accepts integers with exponents or fractions, e.g. 1e2 and 1.0
+	FuzzyDecodeExponentInteger
+	// FuzzyDecodeSpaces accepts leading and trailing spaces in numeric strings, e.g. " 1"
+	FuzzyDecodeSpaces
+
+	// FuzzyDecodeAll is all of the rules above
+	FuzzyDecodeAll = 1<<iota - 1
+)
+
+// fuzzyDecode reports whether the rule is enabled, all of the rules are enabled if FuzzyDecodeOptions is not set
+func (e *ProtoExtension) fuzzyDecode(rule FuzzyDecodeOptions) bool {
+	if e.Strict {
+		return strictFuzzyDecodeOptions&rule != 0
+	}
+	if e.DisableFuzzyDecode {
+		return false
+	}
+	if e.FuzzyDecodeOptions == 0 {
+		return true
+	}
+	return e.FuzzyDecodeOptions&rule != 0
+}
+
+// fieldDescriptorOfBinding returns the descriptor of the field of md by the protobuf tag, nil if unknown
+func fieldDescriptorOfBinding(md protoreflect.MessageDescriptor, binding *jsoniter.Binding) protoreflect.FieldDescriptor {
+	if md == nil {
+		return nil
+	}
+	tag, hastag := binding.Field.Tag().Lookup("protobuf")
+	if !hastag {
+		return nil
+	}
+	parts := strings.Split(tag, ",")
+	for _, part := range parts {
+		if strings.HasPrefix(part, "name=") {
+			if fd := md.Fields().ByName(protoreflect.Name(strings.TrimPrefix(part, "name="))); fd != nil {
+				return fd
+			}
+		}
+	}
+	// the name of a group field is the name of its message type, e.g. "OptGroup", so it is found by the number
+	if len(parts) > 1 {
+		if n, err := strconv.ParseInt(parts[1], 10, 32); err == nil {
+			return md.Fields().ByNumber(protoreflect.FieldNumber(n))
+		}
+	}
+	return nil
+}
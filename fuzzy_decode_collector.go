@@ -0,0 +1,132 @@
+package jsoniterpb
+
+import (
+	"io"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var fuzzyDecodeRuleNames = []string{
+	"NumericString",
+	"NumberToString",
+	"BoolToNumber",
+	"NumberToBool",
+	"BoolString",
+	"NullToZero",
+	"EnumNumericString",
+	"ExponentInteger",
+	"Spaces",
+}
+
+// String returns the names of the rules, e.g. "NumericString|Spaces"
+func (o FuzzyDecodeOptions) String() string {
+	var names []string
+	for i, name := range fuzzyDecodeRuleNames {
+		if o&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// FuzzyDecodeReport tells the input accepted by a fuzzy decode rule
+type FuzzyDecodeReport struct {
+	// Path is the JSON path of the value, e.g. `a.b[3].c` or `m["k"]`, the same as Error
+	Path string
+	// FieldName is the full name of the innermost proto field on the path, empty if there is none
+	FieldName protoreflect.FullName
+	Rule      FuzzyDecodeOptions
+}
+
+// FuzzyDecodeCollector collects the fuzzy decode rules which accept the input,
+// it works by setting to Iterator.Attachment, e.g.
+//
+//	c := &jsoniterpb.FuzzyDecodeCollector{}
+//	iter := api.BorrowIterator(data)
+//	iter.Attachment = c
+//	iter.ReadVal(m)
+//	fmt.Println(c.Reports)
+type FuzzyDecodeCollector struct {
+	// OnReport is called for each report if set, otherwise the reports are appended to Reports
+	OnReport func(r FuzzyDecodeReport)
+	Reports  []FuzzyDecodeReport
+
+	frames []fuzzyDecodeFrame
+}
+
+// fuzzyDecodeFrame is a segment of the path being decoded
+type fuzzyDecodeFrame struct {
+	name  string
+	fd    protoreflect.FieldDescriptor
+	index int
+	key   *string
+}
+
+func fuzzyDecodeCollectorOf(iter *jsoniter.Iterator) *FuzzyDecodeCollector {
+	attachment := iter.Attachment
+	if st, ok := attachment.(*callState); ok {
+		attachment = st.attachment
+	}
+	c, _ := attachment.(*FuzzyDecodeCollector)
+	return c
+}
+
+// pushField adds the field to the path until pop is called
+func (c *FuzzyDecodeCollector) pushField(name string, fd protoreflect.FieldDescriptor) {
+	c.frames = append(c.frames, fuzzyDecodeFrame{name: name, fd: fd, index: -1})
+}
+
+// pushIndex adds the index of a list element to the path until pop is called
+func (c *FuzzyDecodeCollector) pushIndex(i int) {
+	c.frames = append(c.frames, fuzzyDecodeFrame{index: i})
+}
+
+// pushKey adds the key of a map entry to the path until pop is called
+func (c *FuzzyDecodeCollector) pushKey(key string) {
+	c.frames = append(c.frames, fuzzyDecodeFrame{index: -1, key: &key})
+}
+
+func (c *FuzzyDecodeCollector) pop() {
+	c.frames = c.frames[:len(c.frames)-1]
+}
+
+func (c *FuzzyDecodeCollector) report(rule FuzzyDecodeOptions) {
+	// the path is built in the same way as Error
+	e := &Error{}
+	for i := len(c.frames) - 1; i >= 0; i-- {
+		f := c.frames[i]
+		switch {
+		case f.key != nil:
+			e.prependKey(*f.key)
+		case f.index >= 0:
+			e.prependIndex(f.index)
+		default:
+			e.prependField(f.name, f.fd)
+		}
+	}
+
+	r := FuzzyDecodeReport{Path: e.Path, FieldName: e.FieldName, Rule: rule}
+	if c.OnReport != nil {
+		c.OnReport(r)
+		return
+	}
+	c.Reports = append(c.Reports, r)
+}
+
+// reportFuzzyDecode reports each of the rules which accept the input to the collector of iter if there is one
+func reportFuzzyDecode(iter *jsoniter.Iterator, rules FuzzyDecodeOptions) {
+	if rules == 0 || (iter.Error != nil && iter.Error != io.EOF) {
+		return
+	}
+	c := fuzzyDecodeCollectorOf(iter)
+	if c == nil {
+		return
+	}
+	for i := range fuzzyDecodeRuleNames {
+		if rule := FuzzyDecodeOptions(1 << i); rules&rule != 0 {
+			c.report(rule)
+		}
+	}
+}
@@ -25,10 +25,19 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 				valueType := iter.WhatIsNext()
 				switch valueType {
 				case jsoniter.NumberValue:
+					if !e.fuzzyDecode(FuzzyDecodeNumberToString) {
+						dec.Decode(ptr, iter)
+						return
+					}
 					var number json.Number
 					iter.ReadVal(&number)
 					*((*string)(ptr)) = string(number)
+					reportFuzzyDecode(iter, FuzzyDecodeNumberToString)
 				case jsoniter.NilValue:
+					if !e.fuzzyDecode(FuzzyDecodeNullToZero) {
+						dec.Decode(ptr, iter)
+						return
+					}
 					iter.Skip()
 					*((*string)(ptr)) = ""
 				default:
@@ -41,37 +50,42 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 		return &funcDecoder{
 			fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 				valueType := iter.WhatIsNext()
-				var str string
+				offset := iterOffset(iter)
 				switch valueType {
 				case jsoniter.NumberValue:
 					dec.Decode(ptr, iter)
 				case jsoniter.StringValue:
-					str = iter.ReadString()
-					switch str {
-					case "true":
-						str = "1"
-					case "false":
-						str = "0"
+					str, rules, ok := fuzzyNumericString(e, iter.ReadString(), false)
+					if !ok {
+						reportFuzzyError(iter, offset, "fuzzyFloat32Decoder", fmt.Sprintf("string %q is not allowed", str))
+						return
 					}
 					newIter := iter.Pool().BorrowIterator([]byte(str))
-					newIter.Attachment = iter.Attachment
 					defer iter.Pool().ReturnIterator(newIter)
 					*((*float32)(ptr)) = newIter.ReadFloat32()
-					if newIter.Error != nil && newIter.Error != io.EOF {
-						iter.Error = newIter.Error
-					}
+					transferFuzzyError(iter, newIter, offset)
+					reportFuzzyDecode(iter, rules)
 				case jsoniter.BoolValue:
+					if !e.fuzzyDecode(FuzzyDecodeBoolToNumber) {
+						reportFuzzyError(iter, offset, "fuzzyFloat32Decoder", "bool is not allowed")
+						return
+					}
 					// support bool to float32
 					if iter.ReadBool() {
 						*((*float32)(ptr)) = 1
 					} else {
 						*((*float32)(ptr)) = 0
 					}
+					reportFuzzyDecode(iter, FuzzyDecodeBoolToNumber)
 				case jsoniter.NilValue:
+					if !e.fuzzyDecode(FuzzyDecodeNullToZero) {
+						dec.Decode(ptr, iter)
+						return
+					}
 					iter.Skip()
 					*((*float32)(ptr)) = 0
 				default:
-					iter.ReportError("fuzzyFloat32Decoder", "not number or string")
+					reportFuzzyError(iter, offset, "fuzzyFloat32Decoder", "not number or string")
 				}
 			},
 		}
@@ -80,43 +94,48 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 		return &funcDecoder{
 			fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 				valueType := iter.WhatIsNext()
-				var str string
+				offset := iterOffset(iter)
 				switch valueType {
 				case jsoniter.NumberValue:
 					dec.Decode(ptr, iter)
 				case jsoniter.StringValue:
-					str = iter.ReadString()
-					switch str {
-					case "true":
-						str = "1"
-					case "false":
-						str = "0"
+					str, rules, ok := fuzzyNumericString(e, iter.ReadString(), false)
+					if !ok {
+						reportFuzzyError(iter, offset, "fuzzyFloat64Decoder", fmt.Sprintf("string %q is not allowed", str))
+						return
 					}
 					newIter := iter.Pool().BorrowIterator([]byte(str))
-					newIter.Attachment = iter.Attachment
 					defer iter.Pool().ReturnIterator(newIter)
 					*((*float64)(ptr)) = newIter.ReadFloat64()
-					if newIter.Error != nil && newIter.Error != io.EOF {
-						iter.Error = newIter.Error
-					}
+					transferFuzzyError(iter, newIter, offset)
+					reportFuzzyDecode(iter, rules)
 				case jsoniter.BoolValue:
+					if !e.fuzzyDecode(FuzzyDecodeBoolToNumber) {
+						reportFuzzyError(iter, offset, "fuzzyFloat64Decoder", "bool is not allowed")
+						return
+					}
 					// support bool to float64
 					if iter.ReadBool() {
 						*((*float64)(ptr)) = 1
 					} else {
 						*((*float64)(ptr)) = 0
 					}
+					reportFuzzyDecode(iter, FuzzyDecodeBoolToNumber)
 				case jsoniter.NilValue:
+					if !e.fuzzyDecode(FuzzyDecodeNullToZero) {
+						dec.Decode(ptr, iter)
+						return
+					}
 					iter.Skip()
 					*((*float64)(ptr)) = 0
 				default:
-					iter.ReportError("fuzzyFloat64Decoder", "not number or string")
+					reportFuzzyError(iter, offset, "fuzzyFloat64Decoder", "not number or string")
 				}
 			},
 		}
 	},
 	reflect.Bool: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Bool, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			var bint int
 			if isFloat {
 				val := iter.ReadFloat64()
@@ -137,10 +156,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 				iter.ReportError("fuzzy decode bool", fmt.Sprintf("invalid bool(%d)", bint))
 				return
 			}
-		}}
+		})
 	},
 	reflect.Int: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Int, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -155,10 +174,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Int8: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Int8, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -173,10 +192,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Int16: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Int16, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -191,10 +210,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Int32: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Int32, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -209,10 +228,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Int64: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Int64, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -227,10 +246,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Uint: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Uint, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -245,10 +264,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Uint8: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Uint8, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -263,10 +282,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Uint16: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Uint16, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -281,10 +300,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Uint32: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Uint32, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -299,10 +318,10 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 	reflect.Uint64: func(e *ProtoExtension, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
-		return &fuzzyIntegerDecoder{func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		return newFuzzyIntegerDecoder(e, dec, reflect.Uint64, func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if isFloat {
 				val := iter.ReadFloat64()
 				if _, frac := math.Modf(val); frac != 0 {
@@ -317,51 +336,140 @@ var fuzzyDecorateScalarDecoders = map[reflect.Kind]func(e *ProtoExtension, dec j
 			} else {
 				dec.Decode(ptr, iter)
 			}
-		}}
+		})
 	},
 }
 
+// fuzzyNumericString returns the number in str and the rules used if it is allowed by the rules, e.g. " 1" and "true" are "1",
+// alwaysNumeric allows the numeric string regardless of the rules
+func fuzzyNumericString(e *ProtoExtension, str string, alwaysNumeric bool) (string, FuzzyDecodeOptions, bool) {
+	switch str {
+	case "true":
+		return "1", FuzzyDecodeBoolString, e.fuzzyDecode(FuzzyDecodeBoolString)
+	case "false":
+		return "0", FuzzyDecodeBoolString, e.fuzzyDecode(FuzzyDecodeBoolString)
+	}
+	var rules FuzzyDecodeOptions
+	if trimmed := strings.TrimSpace(str); trimmed != str {
+		if !e.fuzzyDecode(FuzzyDecodeSpaces) {
+			return str, 0, false
+		}
+		str = trimmed
+		rules |= FuzzyDecodeSpaces
+	}
+	if alwaysNumeric {
+		return str, rules, true
+	}
+	return str, rules | FuzzyDecodeNumericString, e.fuzzyDecode(FuzzyDecodeNumericString)
+}
+
+// newFuzzyIntegerDecoder returns the fuzzy decoder of integers and bools,
+// fun decodes the number from the iterator of the string which is allowed by the rules, whose error is moved to the iterator of the value
+func newFuzzyIntegerDecoder(e *ProtoExtension, dec jsoniter.ValDecoder, kind reflect.Kind, fun func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator)) *fuzzyIntegerDecoder {
+	return &fuzzyIntegerDecoder{
+		ext:     e,
+		dec:     dec,
+		isBool:  kind == reflect.Bool,
+		is64Bit: kind == reflect.Int64 || kind == reflect.Uint64,
+		fun:     fun,
+	}
+}
+
 type fuzzyIntegerDecoder struct {
-	fun func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator)
+	ext     *ProtoExtension
+	dec     jsoniter.ValDecoder
+	isBool  bool
+	is64Bit bool
+	fun     func(isFloat bool, ptr unsafe.Pointer, iter *jsoniter.Iterator)
 }
 
 func (decoder *fuzzyIntegerDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	e := decoder.ext
 	valueType := iter.WhatIsNext()
+	offset := iterOffset(iter)
 	var str string
+	var rules FuzzyDecodeOptions
 	switch valueType {
 	case jsoniter.NumberValue:
+		if decoder.isBool {
+			if !e.fuzzyDecode(FuzzyDecodeNumberToBool) {
+				reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", "number is not allowed")
+				return
+			}
+			rules |= FuzzyDecodeNumberToBool
+		}
 		var number json.Number
 		iter.ReadVal(&number)
 		str = string(number)
 	case jsoniter.StringValue:
-		str = iter.ReadString()
-		switch str {
-		case "true":
-			str = "1"
-		case "false":
-			str = "0"
+		raw := iter.ReadString()
+		var ok bool
+		// 64-bit integers are always allowed in strings, which is the same as protojson
+		str, rules, ok = fuzzyNumericString(e, raw, decoder.is64Bit || decoder.isBool)
+		if ok && decoder.isBool && raw != "true" && raw != "false" {
+			ok = e.fuzzyDecode(FuzzyDecodeNumberToBool)
+			rules |= FuzzyDecodeNumberToBool
+		}
+		if !ok {
+			reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", fmt.Sprintf("string %q is not allowed", str))
+			return
 		}
 	case jsoniter.BoolValue:
+		if !decoder.isBool {
+			if !e.fuzzyDecode(FuzzyDecodeBoolToNumber) {
+				reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", "bool is not allowed")
+				return
+			}
+			rules |= FuzzyDecodeBoolToNumber
+		}
 		if iter.ReadBool() {
 			str = "1"
 		} else {
 			str = "0"
 		}
 	case jsoniter.NilValue:
+		if !e.fuzzyDecode(FuzzyDecodeNullToZero) {
+			decoder.dec.Decode(ptr, iter)
+			return
+		}
 		iter.Skip()
 		str = "0"
 	default:
-		iter.ReportError("fuzzyIntegerDecoder", "not number or string")
+		reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", "not number or string")
+		return
 	}
 	if len(str) == 0 {
+		// protojson rejects the empty string
+		if e.Strict {
+			reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", `string "" is not allowed`)
+			return
+		}
 		str = "0"
 	}
+	isFloat := strings.ContainsAny(str, ".eE")
+	if isFloat {
+		if !e.fuzzyDecode(FuzzyDecodeExponentInteger) {
+			reportFuzzyError(iter, offset, "fuzzyIntegerDecoder", fmt.Sprintf("%q is not an integer", str))
+			return
+		}
+		rules |= FuzzyDecodeExponentInteger
+	}
 	newIter := iter.Pool().BorrowIterator([]byte(str))
-	newIter.Attachment = iter.Attachment
 	defer iter.Pool().ReturnIterator(newIter)
-	isFloat := strings.ContainsAny(str, ".eE")
 	decoder.fun(isFloat, ptr, newIter)
-	if newIter.Error != nil && newIter.Error != io.EOF {
-		iter.Error = newIter.Error
+	transferFuzzyError(iter, newIter, offset)
+	reportFuzzyDecode(iter, rules)
+}
+
+// reportFuzzyError reports the failure of the value starting at offset
+func reportFuzzyError(iter *jsoniter.Iterator, offset int, operation string, msg string) {
+	reportIterError(iter, offset, fmt.Errorf("%s: %s", operation, msg))
+}
+
+// transferFuzzyError moves the error of newIter, which reads the string of the value starting at offset, to iter
+func transferFuzzyError(iter *jsoniter.Iterator, newIter *jsoniter.Iterator, offset int) {
+	if newIter.Error == io.EOF {
+		return
 	}
+	transferIterError(iter, newIter, func(int) int { return offset })
 }
@@ -0,0 +1,143 @@
+package jsoniterpb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// jsoniter writes the indention by the depth of the stream, which starts from zero in sub streams,
+// e.g. the ones used by google.protobuf.Any, extension fields and sorted map keys,
+// and it writes "{\n}" for a message without populated fields.
+// So the output of the outermost message or map is re-indented as a whole if IndentionStep is set,
+// which is the same as the Multiline format of protojson, and the ones inside it are left to it.
+// The structs, the slices and the arrays which may contain them count the depth of the outermost one in the state of the call,
+// since the stream may be flushed to its writer, the indention is never found from the written output.
+
+func (e *ProtoExtension) decorateEncoderForIndention(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	isMessage := reflect2.PtrTo(typ).Implements(protoMessageType)
+	if typ.Kind() == reflect.Ptr {
+		// e.g. *structpb.Struct, whose codec is not created from the elem type
+		_, isMessage = ProtoCodecs[typ]
+	}
+	if isMessage || typ.Kind() == reflect.Map {
+		return &protoIndentionEncoder{
+			valueEncoder: encoder,
+		}
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array:
+		if containsType(typ.Type1(), isIndentedType, map[reflect.Type]bool{}) {
+			return &indentionDepthEncoder{
+				valueEncoder: encoder,
+			}
+		}
+	}
+	return nil
+}
+
+// isIndentedType reports whether typ is re-indented by protoIndentionEncoder, or it may be in an interface
+func isIndentedType(typ reflect.Type) bool {
+	return isProtoType(typ) || typ.Kind() == reflect.Map || typ.Kind() == reflect.Interface
+}
+
+// indentionOf returns the indention of the config of stream, empty if IndentionStep is not set
+func indentionOf(stream *jsoniter.Stream) string {
+	if fcfg, ok := stream.API().(interface {
+		GetConfig() jsoniter.Config
+	}); ok && fcfg.GetConfig().IndentionStep > 0 {
+		return strings.Repeat(" ", fcfg.GetConfig().IndentionStep)
+	}
+	return ""
+}
+
+type protoIndentionEncoder struct {
+	valueEncoder jsoniter.ValEncoder
+
+	once   sync.Once
+	indent string
+}
+
+func (enc *protoIndentionEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	enc.once.Do(func() {
+		enc.indent = indentionOf(stream)
+	})
+	if enc.indent == "" {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	st, ok := enterStreamCall(stream)
+	if ok {
+		defer leaveStreamCall(stream, st)
+	}
+	if st.indenting {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	st.indenting = true
+	defer func() {
+		st.indenting = false
+	}()
+
+	subStream := stream.API().BorrowStream(nil)
+	subStream.Attachment = stream.Attachment
+	defer stream.API().ReturnStream(subStream)
+	enc.valueEncoder.Encode(ptr, subStream)
+	if subStream.Error != nil && subStream.Error != io.EOF {
+		transferStreamError(stream, subStream)
+		return
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, subStream.Buffer(), strings.Repeat(enc.indent, st.depth), enc.indent); err != nil {
+		reportStreamError(stream, err)
+		return
+	}
+	stream.Write(out.Bytes())
+}
+
+func (enc *protoIndentionEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
+
+// indentionDepthEncoder counts the depth of the objects and the arrays written by jsoniter outside the outermost message or map
+type indentionDepthEncoder struct {
+	valueEncoder jsoniter.ValEncoder
+
+	once   sync.Once
+	indent string
+}
+
+func (enc *indentionDepthEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	enc.once.Do(func() {
+		enc.indent = indentionOf(stream)
+	})
+	if enc.indent == "" {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	st, ok := enterStreamCall(stream)
+	if ok {
+		defer leaveStreamCall(stream, st)
+	}
+	if st.indenting {
+		enc.valueEncoder.Encode(ptr, stream)
+		return
+	}
+	st.depth++
+	defer func() {
+		st.depth--
+	}()
+	enc.valueEncoder.Encode(ptr, stream)
+}
+
+func (enc *indentionDepthEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
@@ -24,6 +24,9 @@ func run() error {
 	if err := downloadPb3(); err != nil {
 		return err
 	}
+	if err := downloadPb2(); err != nil {
+		return err
+	}
 	if err := handleEncode(); err != nil {
 		return err
 	}
@@ -41,6 +44,14 @@ func downloadPb3() error {
 	return ioutil.WriteFile("./textpb3/test.pb.go", []byte(f.Body), 0644)
 }
 
+func downloadPb2() error {
+	f, err := fetchGithubFile("protocolbuffers/protobuf-go", "internal/testprotos/textpb2/test.pb.go")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile("./textpb2/test.pb.go", []byte(f.Body), 0644)
+}
+
 func handleEncode() error {
 	f, err := fetchGithubFile("protocolbuffers/protobuf-go", "encoding/protojson/encode_test.go")
 	if err != nil {
@@ -50,7 +61,8 @@ func handleEncode() error {
 	// \{\s*desc:[\s\S]+?input:[\s\S]+?,\s*(want:\s*`[^`]+`|wantErr:\s*(true|false)),\s*\}(,|})
 	r := regexp.MustCompile(`\{\s*desc:[\s\S]+?input:[\s\S]+?,\s*(want:\s*` + "`[^`]+`" + `|wantErr:\s*(true|false)),\s*\}(,|})`)
 	f.Body = r.ReplaceAllStringFunc(f.Body, func(src string) string {
-		if strings.Contains(src, "pb2.") {
+		if strings.Contains(src, "testpb.") ||
+			strings.Contains(src, "protopack.") {
 			if strings.HasSuffix(src, "}") {
 				return "}"
 			}
@@ -62,17 +74,19 @@ func handleEncode() error {
 		`
 import (
 	"bytes"
-	"encoding/json"
 	"math"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/molon/jsoniterpb"
+	pb2 "github.com/molon/jsoniterpb/internal/protojson/textpb2"
 	pb3 "github.com/molon/jsoniterpb/internal/protojson/textpb3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -83,51 +97,66 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )`)
 	f.Body = regexp.MustCompile(`for _, tt := range tests \{[\s\S]+$`).ReplaceAllString(f.Body,
-		`
+		strings.ReplaceAll(`
+	const ignores = PLACEHOLDER
+	[CheckRequiredInAny] Any with missing required
+	PLACEHOLDER
+
+	ignoreDescs := map[string]string{}
+	r := regexp.MustCompile("\\[([^\\]]+)\\]\\s*(.+)")
+	for _, v := range strings.Split(ignores, "\n") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		es := r.FindAllStringSubmatch(v, -1)
+		ignoreDescs[strings.TrimSpace(es[0][2])] = strings.TrimSpace(es[0][1])
+	}
+
 	for _, tt := range tests {
 		tt := tt
 		if tt.skip {
 			continue
 		}
+
+		if sign, ok := ignoreDescs[tt.desc]; ok {
+			switch sign {
+			case "CheckRequiredInAny":
+				continue
+			}
+		}
+
 		t.Run(tt.desc, func(t *testing.T) {
-			cfg := jsoniter.Config{SortMapKeys: true}.Froze()
-			cfg.RegisterExtension(&jsoniterpb.ProtoExtension{
+			b, err := jsoniterpb.MarshalOptions{
+				Indent:          "  ",
 				UseEnumNumbers:  tt.mo.UseEnumNumbers,
 				UseProtoNames:   tt.mo.UseProtoNames,
 				EmitUnpopulated: tt.mo.EmitUnpopulated,
 				Resolver:        tt.mo.Resolver,
-			})
-			b, err := cfg.MarshalIndent(tt.input, "", "  ")
+				AllowPartial:    tt.mo.AllowPartial,
+			}.Marshal(tt.input)
 			if err != nil && !tt.wantErr {
-				t.Errorf("MarshalIndent() returned error: %v\n", err)
+				t.Errorf("Marshal() returned error: %v\n", err)
 			}
 			if err == nil && tt.wantErr {
-				t.Errorf("MarshalIndent() got nil error, want error\n")
+				t.Errorf("Marshal() got nil error, want error\n")
+			}
+			if err != nil && tt.wantErr {
+				// jsoniter does not return the partial output along with the error
+				return
 			}
 			got := string(b)
-			if compact(t, got) != compact(t, tt.want) {
-				t.Errorf("MarshalIndent()\n<want>\n%v\n<got>\n%v\n", tt.want, got)
+			if got != tt.want {
+				t.Errorf("Marshal()\n<want>\n%v\n<got>\n%v\n", tt.want, got)
 				if diff := cmp.Diff(tt.want, got); diff != "" {
-					t.Errorf("MarshalIndent() diff -want +got\n%v\n", diff)
+					t.Errorf("Marshal() diff -want +got\n%v\n", diff)
 				}
 			}
 		})
 	}
 }
-
-func compact(t *testing.T, want string) string {
-	if want == "" {
-		return want
-	}
-	var out bytes.Buffer
-	err := json.Compact(&out, []byte(want))
-	if err != nil {
-		t.Errorf("Compact returned error: %v\n", err)
-		return want
-	}
-	return out.String()
-}
-`)
+`, "PLACEHOLDER", "`"))
+	f.Body = replaceInternalRefs(f.Body)
 	f.Body = fmt.Sprintf(`
 // generated by go run gen.go; DO NOT EDIT
 // github.com/protocolbuffers/protobuf-go
@@ -156,8 +185,7 @@ func handleDecode() error {
 	r := regexp2.MustCompile(`\{\s*desc:[\s\S]+?(?=(\{\s*desc:|\}\s*for))`, 0)
 	f.Body, err = r.ReplaceFunc(f.Body, func(m regexp2.Match) string {
 		src := m.GroupByNumber(0).String()
-		if strings.Contains(src, "pb2.") ||
-			strings.Contains(src, "testpb.") ||
+		if strings.Contains(src, "testpb.") ||
 			strings.Contains(src, "weakpb.") {
 			return ""
 		}
@@ -175,7 +203,9 @@ func handleDecode() error {
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
+	pb2 "github.com/molon/jsoniterpb/internal/protojson/textpb2"
 	pb3 "github.com/molon/jsoniterpb/internal/protojson/textpb3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -185,7 +215,6 @@ func handleDecode() error {
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/molon/jsoniterpb"
 )`)
 	f.Body = regexp.MustCompile(`for _, tt := range tests \{[\s\S]+$`).ReplaceAllString(f.Body,
@@ -199,6 +228,10 @@ func handleDecode() error {
 	[FuzzyDecode] double string with trailing space
 	[FuzzyDecode] enum set to number string
 	[FuzzyDecode] map contains null for message value
+	[FuzzyDecode] not string
+	[FuzzyDecode] null message
+	[FuzzyDecode] repeated scalars contain invalid type
+	[FuzzyDecode] repeated messages contain invalid type
 	[ErrMsgNotSame] string with invalid UTF-8
 	[ErrMsgNotSame] camelCase name
 	[ErrMsgNotSame] message set to non-message
@@ -216,12 +249,20 @@ func handleDecode() error {
 	[ErrMsgNotSame] Any with missing @type
 	[ErrMsgNotSame] Any with empty @type
 	[ErrMsgNotSame] Any with duplicate value
-	[NotSupport] proto name and json_name
-	[NotSupport] duplicate field names
-	[NotSupport] oneof set to more than one field
-	[NotSupport] oneof set to null and value
-	[NotSupport] map contains duplicate keys
-	[NotSupport] DiscardUnknown: Any without type
+	[ErrMsgNotSame] unexpected value instead of EOF
+	[ErrMsgNotSame] invalid extension field name
+	[ErrMsgNotSame] extensions of repeated field contains null
+	[ErrMsgNotSame] Value field list with invalid UTF8 string
+	[ErrMsgNotSame] Any without registered type
+	[ErrMsgNotSame] Any with invalid UTF8
+	[ErrMsgNotSame] Any with duplicate @type
+	[ErrMsgNotSame] Any with unknown field
+	[ErrMsgNotSame] Any with embedded type containing Any
+	[ErrMsgNotSame] proto name and json_name
+	[ErrMsgNotSame] duplicate field names
+	[ErrMsgNotSame] oneof set to more than one field
+	[ErrMsgNotSame] map contains duplicate keys
+	[CheckRequiredInAny] Any with missing required
 	PLACEHOLDER
 	
 	ignoreDescs := map[string]string{}
@@ -241,41 +282,62 @@ func handleDecode() error {
 			continue
 		}
 
-		if sign,ok := ignoreDescs[tt.desc];ok {
-			switch sign {
-			case "FuzzyDecode","NotSupport","ErrMsgNotSame":
-				continue
-			}
+		sign := ignoreDescs[tt.desc]
+		switch sign {
+		case "CheckRequiredInAny":
+			continue
 		}
 
-		t.Run(tt.desc, func(t *testing.T) {
-			cfg := jsoniter.Config{SortMapKeys: true,DisallowUnknownFields: !tt.umo.DiscardUnknown}.Froze()
-			cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: tt.umo.Resolver})
-			err := cfg.Unmarshal([]byte(tt.inputText), tt.inputMessage)
-			if err != nil {
-				if tt.wantErr == "" {
-					t.Errorf("Unmarshal() got unexpected error: %v", err)
-				} else {
-					if strings.Contains(tt.wantErr, "invalid value for") {
+		// the input accepted by the fuzzy decode is only rejected with Strict,
+		// and the error messages are checked with ProtojsonErrors, or if they are the same as protojson
+		for _, strict := range []bool{false, true} {
+			for _, protojsonErrors := range []bool{false, true} {
+				strict, protojsonErrors := strict, protojsonErrors
+				if sign == "FuzzyDecode" && !strict {
+					continue
+				}
+				desc := tt.desc
+				if protojsonErrors {
+					desc = "ProtojsonErrors/" + desc
+				}
+				if strict {
+					desc = "Strict/" + desc
+				}
+				t.Run(desc, func(t *testing.T) {
+					err := jsoniterpb.UnmarshalOptions{
+						DiscardUnknown:  tt.umo.DiscardUnknown,
+						Resolver:        tt.umo.Resolver,
+						AllowPartial:    tt.umo.AllowPartial,
+						ProtojsonErrors: protojsonErrors,
+						Strict:          strict,
+					}.Unmarshal([]byte(tt.inputText), tt.inputMessage)
+					if err != nil {
+						if tt.wantErr == "" {
+							t.Errorf("Unmarshal() got unexpected error: %v", err)
+						} else {
+							if !protojsonErrors && (strings.Contains(tt.wantErr, "invalid value for") || sign == "ErrMsgNotSame" || sign == "FuzzyDecode") {
+								return
+							}
+							if !strings.Contains(err.Error(), tt.wantErr) {
+								t.Errorf("Unmarshal() error got %q, want %q", err, tt.wantErr)
+							}
+						} 
 						return
 					}
-					if !strings.Contains(err.Error(), tt.wantErr) {
-						t.Errorf("Unmarshal() error got %q, want %q", err, tt.wantErr)
+					if tt.wantErr != "" {
+						t.Errorf("Unmarshal() got nil error, want error %q", tt.wantErr)
 					}
-				} 
-				return
-			}
-			if tt.wantErr != "" {
-				t.Errorf("Unmarshal() got nil error, want error %q", tt.wantErr)
-			}
-			if tt.wantMessage != nil && !proto.Equal(tt.inputMessage, tt.wantMessage) {
-				t.Errorf("Unmarshal()\n<got>\n%v\n<want>\n%v\n", tt.inputMessage, tt.wantMessage)
+					if tt.wantMessage != nil && !proto.Equal(tt.inputMessage, tt.wantMessage) {
+						t.Errorf("Unmarshal()\n<got>\n%v\n<want>\n%v\n", tt.inputMessage, tt.wantMessage)
+					}
+				})
 			}
-		})
+		}
 	}
 }	
 
 `, "PLACEHOLDER", "`"))
+	f.Body = replaceInternalRefs(f.Body)
 	f.Body = fmt.Sprintf(`
 // generated by go run gen.go; DO NOT EDIT
 // github.com/protocolbuffers/protobuf-go
@@ -287,6 +349,18 @@ func handleDecode() error {
 	return ioutil.WriteFile("./tests/jsoniterpb_decode_test.go", []byte(f.Body), 0644)
 }
 
+// replaceInternalRefs replaces the references of the internal packages of protobuf-go which can not be imported
+func replaceInternalRefs(body string) string {
+	// errors.RequiredNotSet("pb2.Requireds.req_bool").Error()
+	body = regexp.MustCompile(`errors\.RequiredNotSet\(("[^"]+")\)\.Error\(\)`).ReplaceAllStringFunc(body, func(src string) string {
+		name := regexp.MustCompile(`"([^"]+)"`).FindStringSubmatch(src)[1]
+		return fmt.Sprintf("%q", "required field "+name+" not set")
+	})
+	// legacy MessageSet is not supported
+	body = strings.ReplaceAll(body, "!flags.ProtoLegacy", "true")
+	return body
+}
+
 var (
 	shaRE  = regexp.MustCompile(`"sha":"([^"]+)"`)
 	dateRE = regexp.MustCompile(`"committer":{[^{]+"date":"([^"]+)"`)
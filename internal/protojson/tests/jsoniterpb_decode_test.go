@@ -18,7 +18,9 @@ import (
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
+	pb2 "github.com/molon/jsoniterpb/internal/protojson/textpb2"
 	pb3 "github.com/molon/jsoniterpb/internal/protojson/textpb3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -28,7 +30,6 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/molon/jsoniterpb"
 )
 
@@ -42,6 +43,53 @@ func TestUnmarshal(t *testing.T) {
 		wantErr      string // Expected error substring.
 		skip         bool
 	}{{
+		desc:         "proto2 empty message",
+		inputMessage: &pb2.Scalars{},
+		inputText:    "{}",
+		wantMessage:  &pb2.Scalars{},
+	}, {
+		desc:         "unexpected value instead of EOF",
+		inputMessage: &pb2.Scalars{},
+		inputText:    "{} {}",
+		wantErr:      `(line 1:4): unexpected token {`,
+	}, {
+		desc:         "proto2 optional scalars set to zero values",
+		inputMessage: &pb2.Scalars{},
+		inputText: `{
+  "optBool": false,
+  "optInt32": 0,
+  "optInt64": 0,
+  "optUint32": 0,
+  "optUint64": 0,
+  "optSint32": 0,
+  "optSint64": 0,
+  "optFixed32": 0,
+  "optFixed64": 0,
+  "optSfixed32": 0,
+  "optSfixed64": 0,
+  "optFloat": 0,
+  "optDouble": 0,
+  "optBytes": "",
+  "optString": ""
+}`,
+		wantMessage: &pb2.Scalars{
+			OptBool:     proto.Bool(false),
+			OptInt32:    proto.Int32(0),
+			OptInt64:    proto.Int64(0),
+			OptUint32:   proto.Uint32(0),
+			OptUint64:   proto.Uint64(0),
+			OptSint32:   proto.Int32(0),
+			OptSint64:   proto.Int64(0),
+			OptFixed32:  proto.Uint32(0),
+			OptFixed64:  proto.Uint64(0),
+			OptSfixed32: proto.Int32(0),
+			OptSfixed64: proto.Int64(0),
+			OptFloat:    proto.Float32(0),
+			OptDouble:   proto.Float64(0),
+			OptBytes:    []byte{},
+			OptString:   proto.String(""),
+		},
+	}, {
 		desc:         "proto3 scalars set to zero values",
 		inputMessage: &pb3.Scalars{},
 		inputText: `{
@@ -91,6 +139,27 @@ func TestUnmarshal(t *testing.T) {
 			OptEnum:    pb3.Enum_ZERO.Enum(),
 			OptMessage: &pb3.Nested{},
 		},
+	}, {
+		desc:         "proto2 optional scalars set to null",
+		inputMessage: &pb2.Scalars{},
+		inputText: `{
+  "optBool": null,
+  "optInt32": null,
+  "optInt64": null,
+  "optUint32": null,
+  "optUint64": null,
+  "optSint32": null,
+  "optSint64": null,
+  "optFixed32": null,
+  "optFixed64": null,
+  "optSfixed32": null,
+  "optSfixed64": null,
+  "optFloat": null,
+  "optDouble": null,
+  "optBytes": null,
+  "optString": null
+}`,
+		wantMessage: &pb2.Scalars{},
 	}, {
 		desc:         "proto3 scalars set to null",
 		inputMessage: &pb3.Scalars{},
@@ -317,11 +386,23 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &pb3.Scalars{},
 		inputText:    `{"sUint32": -1}`,
 		wantErr:      `invalid value for uint32 type: -1`,
+	}, {
+		desc:         "string",
+		inputMessage: &pb2.Scalars{},
+		inputText:    `{"optString": "谷歌"}`,
+		wantMessage: &pb2.Scalars{
+			OptString: proto.String("谷歌"),
+		},
 	}, {
 		desc:         "string with invalid UTF-8",
 		inputMessage: &pb3.Scalars{},
 		inputText:    "{\"sString\": \"\xff\"}",
 		wantErr:      `(line 1:13): invalid UTF-8 in string`,
+	}, {
+		desc:         "not string",
+		inputMessage: &pb2.Scalars{},
+		inputText:    `{"optString": 42}`,
+		wantErr:      `invalid value for string type: 42`,
 	}, {
 		desc:         "bytes",
 		inputMessage: &pb3.Scalars{},
@@ -341,6 +422,17 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &pb3.Scalars{},
 		inputText:    `{"sBytes": true}`,
 		wantErr:      `invalid value for bytes type: true`,
+	}, {
+		desc:         "proto2 enum",
+		inputMessage: &pb2.Enums{},
+		inputText: `{
+  "optEnum": "ONE",
+  "optNestedEnum": "UNO"
+}`,
+		wantMessage: &pb2.Enums{
+			OptEnum:       pb2.Enum_ONE.Enum(),
+			OptNestedEnum: pb2.Enums_UNO.Enum(),
+		},
 	}, {
 		desc:         "proto3 enum",
 		inputMessage: &pb3.Enums{},
@@ -411,6 +503,17 @@ func TestUnmarshal(t *testing.T) {
 		wantMessage: &pb3.JSONNames{
 			SString: "proto name used",
 		},
+	}, {
+		desc:         "proto group name",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+		"OptGroup": {"optString": "hello"},
+		"RptGroup": [{"rptString": ["goodbye"]}]
+	}`,
+		wantMessage: &pb2.Nests{
+			Optgroup: &pb2.Nests_OptGroup{OptString: proto.String("hello")},
+			Rptgroup: []*pb2.Nests_RptGroup{{RptString: []string{"goodbye"}}},
+		},
 	}, {
 		desc:         "json_name",
 		inputMessage: &pb3.JSONNames{},
@@ -443,6 +546,79 @@ func TestUnmarshal(t *testing.T) {
   "foo_bar": "two",
 }`,
 		wantErr: `(line 3:3): duplicate field "foo_bar"`,
+	}, {
+		desc:         "null message",
+		inputMessage: &pb2.Nests{},
+		inputText:    "null",
+		wantErr:      `unexpected token null`,
+	}, {
+		desc:         "proto2 nested message not set",
+		inputMessage: &pb2.Nests{},
+		inputText:    "{}",
+		wantMessage:  &pb2.Nests{},
+	}, {
+		desc:         "proto2 nested message set to null",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "optNested": null,
+  "optgroup": null
+}`,
+		wantMessage: &pb2.Nests{},
+	}, {
+		desc:         "proto2 nested message set to empty",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "optNested": {},
+  "optgroup": {}
+}`,
+		wantMessage: &pb2.Nests{
+			OptNested: &pb2.Nested{},
+			Optgroup:  &pb2.Nests_OptGroup{},
+		},
+	}, {
+		desc:         "proto2 nested messages",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "optNested": {
+    "optString": "nested message",
+    "optNested": {
+      "optString": "another nested message"
+    }
+  }
+}`,
+		wantMessage: &pb2.Nests{
+			OptNested: &pb2.Nested{
+				OptString: proto.String("nested message"),
+				OptNested: &pb2.Nested{
+					OptString: proto.String("another nested message"),
+				},
+			},
+		},
+	}, {
+		desc:         "proto2 groups",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "optgroup": {
+    "optString": "inside a group",
+    "optNested": {
+      "optString": "nested message inside a group"
+    },
+    "optnestedgroup": {
+      "optFixed32": 47
+    }
+  }
+}`,
+		wantMessage: &pb2.Nests{
+			Optgroup: &pb2.Nests_OptGroup{
+				OptString: proto.String("inside a group"),
+				OptNested: &pb2.Nested{
+					OptString: proto.String("nested message inside a group"),
+				},
+				Optnestedgroup: &pb2.Nests_OptGroup_OptNestedGroup{
+					OptFixed32: proto.Uint32(47),
+				},
+			},
+		},
 	}, {
 		desc:         "proto3 nested message not set",
 		inputMessage: &pb3.Nests{},
@@ -563,6 +739,111 @@ func TestUnmarshal(t *testing.T) {
 				OneofEnum: pb3.Enum_ZERO,
 			},
 		},
+	}, {
+		desc:         "repeated null fields",
+		inputMessage: &pb2.Repeats{},
+		inputText: `{
+  "rptString": null,
+  "rptInt32" : null,
+  "rptFloat" : null,
+  "rptBytes" : null
+}`,
+		wantMessage: &pb2.Repeats{},
+	}, {
+		desc:         "repeated scalars",
+		inputMessage: &pb2.Repeats{},
+		inputText: `{
+  "rptString": ["hello", "world"],
+  "rptInt32" : [-1, 0, 1],
+  "rptBool"  : [false, true]
+}`,
+		wantMessage: &pb2.Repeats{
+			RptString: []string{"hello", "world"},
+			RptInt32:  []int32{-1, 0, 1},
+			RptBool:   []bool{false, true},
+		},
+	}, {
+		desc:         "repeated enums",
+		inputMessage: &pb2.Enums{},
+		inputText: `{
+  "rptEnum"      : ["TEN", 1, 42],
+  "rptNestedEnum": ["DOS", 2, -47]
+}`,
+		wantMessage: &pb2.Enums{
+			RptEnum:       []pb2.Enum{pb2.Enum_TEN, pb2.Enum_ONE, 42},
+			RptNestedEnum: []pb2.Enums_NestedEnum{pb2.Enums_DOS, pb2.Enums_DOS, -47},
+		},
+	}, {
+		desc:         "repeated messages",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "rptNested": [
+    {
+      "optString": "repeat nested one"
+    },
+    {
+      "optString": "repeat nested two",
+      "optNested": {
+        "optString": "inside repeat nested two"
+      }
+    },
+    {}
+  ]
+}`,
+		wantMessage: &pb2.Nests{
+			RptNested: []*pb2.Nested{
+				{
+					OptString: proto.String("repeat nested one"),
+				},
+				{
+					OptString: proto.String("repeat nested two"),
+					OptNested: &pb2.Nested{
+						OptString: proto.String("inside repeat nested two"),
+					},
+				},
+				{},
+			},
+		},
+	}, {
+		desc:         "repeated groups",
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "rptgroup": [
+    {
+      "rptString": ["hello", "world"]
+    },
+    {}
+  ]
+}
+`,
+		wantMessage: &pb2.Nests{
+			Rptgroup: []*pb2.Nests_RptGroup{
+				{
+					RptString: []string{"hello", "world"},
+				},
+				{},
+			},
+		},
+	}, {
+		desc:         "repeated string contains invalid UTF8",
+		inputMessage: &pb2.Repeats{},
+		inputText:    `{"rptString": ["` + "abc\xff" + `"]}`,
+		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "repeated messages contain invalid UTF8",
+		inputMessage: &pb2.Nests{},
+		inputText:    `{"rptNested": [{"optString": "` + "abc\xff" + `"}]}`,
+		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "repeated scalars contain invalid type",
+		inputMessage: &pb2.Repeats{},
+		inputText:    `{"rptString": ["hello", null, "world"]}`,
+		wantErr:      `invalid value for string type: null`,
+	}, {
+		desc:         "repeated messages contain invalid type",
+		inputMessage: &pb2.Nests{},
+		inputText:    `{"rptNested": [{}, null]}`,
+		wantErr:      `unexpected token null`,
 	}, {
 		desc:         "map fields 1",
 		inputMessage: &pb3.Maps{},
@@ -760,6 +1041,391 @@ func TestUnmarshal(t *testing.T) {
   }
 }`,
 		wantErr: `invalid UTF-8`,
+	}, {
+		desc:         "required fields not set",
+		inputMessage: &pb2.Requireds{},
+		inputText:    `{}`,
+		wantErr:      "required field pb2.Requireds.req_bool not set",
+	}, {
+		desc:         "required field set",
+		inputMessage: &pb2.PartialRequired{},
+		inputText: `{
+  "reqString": "this is required"
+}`,
+		wantMessage: &pb2.PartialRequired{
+			ReqString: proto.String("this is required"),
+		},
+	}, {
+		desc:         "required fields partially set",
+		inputMessage: &pb2.Requireds{},
+		inputText: `{
+  "reqBool": false,
+  "reqSfixed64": 42,
+  "reqString": "hello",
+  "reqEnum": "ONE"
+}`,
+		wantMessage: &pb2.Requireds{
+			ReqBool:     proto.Bool(false),
+			ReqSfixed64: proto.Int64(42),
+			ReqString:   proto.String("hello"),
+			ReqEnum:     pb2.Enum_ONE.Enum(),
+		},
+		wantErr: "required field pb2.Requireds.req_double not set",
+	}, {
+		desc:         "required fields partially set with AllowPartial",
+		umo:          protojson.UnmarshalOptions{AllowPartial: true},
+		inputMessage: &pb2.Requireds{},
+		inputText: `{
+  "reqBool": false,
+  "reqSfixed64": 42,
+  "reqString": "hello",
+  "reqEnum": "ONE"
+}`,
+		wantMessage: &pb2.Requireds{
+			ReqBool:     proto.Bool(false),
+			ReqSfixed64: proto.Int64(42),
+			ReqString:   proto.String("hello"),
+			ReqEnum:     pb2.Enum_ONE.Enum(),
+		},
+	}, {
+		desc:         "required fields all set",
+		inputMessage: &pb2.Requireds{},
+		inputText: `{
+  "reqBool": false,
+  "reqSfixed64": 42,
+  "reqDouble": 1.23,
+  "reqString": "hello",
+  "reqEnum": "ONE",
+  "reqNested": {}
+}`,
+		wantMessage: &pb2.Requireds{
+			ReqBool:     proto.Bool(false),
+			ReqSfixed64: proto.Int64(42),
+			ReqDouble:   proto.Float64(1.23),
+			ReqString:   proto.String("hello"),
+			ReqEnum:     pb2.Enum_ONE.Enum(),
+			ReqNested:   &pb2.Nested{},
+		},
+	}, {
+		desc:         "indirect required field",
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "optNested": {}
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			OptNested: &pb2.NestedWithRequired{},
+		},
+		wantErr: "required field pb2.NestedWithRequired.req_string not set",
+	}, {
+		desc:         "indirect required field with AllowPartial",
+		umo:          protojson.UnmarshalOptions{AllowPartial: true},
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "optNested": {}
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			OptNested: &pb2.NestedWithRequired{},
+		},
+	}, {
+		desc:         "indirect required field in repeated",
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "rptNested": [
+    {"reqString": "one"},
+    {}
+  ]
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			RptNested: []*pb2.NestedWithRequired{
+				{
+					ReqString: proto.String("one"),
+				},
+				{},
+			},
+		},
+		wantErr: "required field pb2.NestedWithRequired.req_string not set",
+	}, {
+		desc:         "indirect required field in repeated with AllowPartial",
+		umo:          protojson.UnmarshalOptions{AllowPartial: true},
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "rptNested": [
+    {"reqString": "one"},
+    {}
+  ]
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			RptNested: []*pb2.NestedWithRequired{
+				{
+					ReqString: proto.String("one"),
+				},
+				{},
+			},
+		},
+	}, {
+		desc:         "indirect required field in map",
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "strToNested": {
+    "missing": {},
+	"contains": {
+      "reqString": "here"
+    }
+  }
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			StrToNested: map[string]*pb2.NestedWithRequired{
+				"missing": &pb2.NestedWithRequired{},
+				"contains": &pb2.NestedWithRequired{
+					ReqString: proto.String("here"),
+				},
+			},
+		},
+		wantErr: "required field pb2.NestedWithRequired.req_string not set",
+	}, {
+		desc:         "indirect required field in map with AllowPartial",
+		umo:          protojson.UnmarshalOptions{AllowPartial: true},
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "strToNested": {
+    "missing": {},
+	"contains": {
+      "reqString": "here"
+    }
+  }
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			StrToNested: map[string]*pb2.NestedWithRequired{
+				"missing": &pb2.NestedWithRequired{},
+				"contains": &pb2.NestedWithRequired{
+					ReqString: proto.String("here"),
+				},
+			},
+		},
+	}, {
+		desc:         "indirect required field in oneof",
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "oneofNested": {}
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			Union: &pb2.IndirectRequired_OneofNested{
+				OneofNested: &pb2.NestedWithRequired{},
+			},
+		},
+		wantErr: "required field pb2.NestedWithRequired.req_string not set",
+	}, {
+		desc:         "indirect required field in oneof with AllowPartial",
+		umo:          protojson.UnmarshalOptions{AllowPartial: true},
+		inputMessage: &pb2.IndirectRequired{},
+		inputText: `{
+  "oneofNested": {}
+}`,
+		wantMessage: &pb2.IndirectRequired{
+			Union: &pb2.IndirectRequired_OneofNested{
+				OneofNested: &pb2.NestedWithRequired{},
+			},
+		},
+	}, {
+		desc:         "extensions of non-repeated fields",
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "optString": "non-extension field",
+  "optBool": true,
+  "optInt32": 42,
+  "[pb2.opt_ext_bool]": true,
+  "[pb2.opt_ext_nested]": {
+    "optString": "nested in an extension",
+    "optNested": {
+      "optString": "another nested in an extension"
+    }
+  },
+  "[pb2.opt_ext_string]": "extension field",
+  "[pb2.opt_ext_enum]": "TEN"
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Extensions{
+				OptString: proto.String("non-extension field"),
+				OptBool:   proto.Bool(true),
+				OptInt32:  proto.Int32(42),
+			}
+			proto.SetExtension(m, pb2.E_OptExtBool, true)
+			proto.SetExtension(m, pb2.E_OptExtString, "extension field")
+			proto.SetExtension(m, pb2.E_OptExtEnum, pb2.Enum_TEN)
+			proto.SetExtension(m, pb2.E_OptExtNested, &pb2.Nested{
+				OptString: proto.String("nested in an extension"),
+				OptNested: &pb2.Nested{
+					OptString: proto.String("another nested in an extension"),
+				},
+			})
+			return m
+		}(),
+	}, {
+		desc:         "extensions of repeated fields",
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "[pb2.rpt_ext_enum]": ["TEN", 101, "ONE"],
+  "[pb2.rpt_ext_fixed32]": [42, 47],
+  "[pb2.rpt_ext_nested]": [
+    {"optString": "one"},
+	{"optString": "two"},
+	{"optString": "three"}
+  ]
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Extensions{}
+			proto.SetExtension(m, pb2.E_RptExtEnum, []pb2.Enum{pb2.Enum_TEN, 101, pb2.Enum_ONE})
+			proto.SetExtension(m, pb2.E_RptExtFixed32, []uint32{42, 47})
+			proto.SetExtension(m, pb2.E_RptExtNested, []*pb2.Nested{
+				&pb2.Nested{OptString: proto.String("one")},
+				&pb2.Nested{OptString: proto.String("two")},
+				&pb2.Nested{OptString: proto.String("three")},
+			})
+			return m
+		}(),
+	}, {
+		desc:         "extensions of non-repeated fields in another message",
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "[pb2.ExtensionsContainer.opt_ext_bool]": true,
+  "[pb2.ExtensionsContainer.opt_ext_enum]": "TEN",
+  "[pb2.ExtensionsContainer.opt_ext_nested]": {
+    "optString": "nested in an extension",
+    "optNested": {
+      "optString": "another nested in an extension"
+    }
+  },
+  "[pb2.ExtensionsContainer.opt_ext_string]": "extension field"
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Extensions{}
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_OptExtBool, true)
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_OptExtString, "extension field")
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_OptExtEnum, pb2.Enum_TEN)
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_OptExtNested, &pb2.Nested{
+				OptString: proto.String("nested in an extension"),
+				OptNested: &pb2.Nested{
+					OptString: proto.String("another nested in an extension"),
+				},
+			})
+			return m
+		}(),
+	}, {
+		desc:         "extensions of repeated fields in another message",
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "optString": "non-extension field",
+  "optBool": true,
+  "optInt32": 42,
+  "[pb2.ExtensionsContainer.rpt_ext_nested]": [
+    {"optString": "one"},
+    {"optString": "two"},
+    {"optString": "three"}
+  ],
+  "[pb2.ExtensionsContainer.rpt_ext_enum]": ["TEN", 101, "ONE"],
+  "[pb2.ExtensionsContainer.rpt_ext_string]": ["hello", "world"]
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Extensions{
+				OptString: proto.String("non-extension field"),
+				OptBool:   proto.Bool(true),
+				OptInt32:  proto.Int32(42),
+			}
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_RptExtEnum, []pb2.Enum{pb2.Enum_TEN, 101, pb2.Enum_ONE})
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_RptExtString, []string{"hello", "world"})
+			proto.SetExtension(m, pb2.E_ExtensionsContainer_RptExtNested, []*pb2.Nested{
+				&pb2.Nested{OptString: proto.String("one")},
+				&pb2.Nested{OptString: proto.String("two")},
+				&pb2.Nested{OptString: proto.String("three")},
+			})
+			return m
+		}(),
+	}, {
+		desc:         "invalid extension field name",
+		inputMessage: &pb2.Extensions{},
+		inputText:    `{ "[pb2.invalid_message_field]": true }`,
+		wantErr:      `(line 1:3): unknown field "[pb2.invalid_message_field]"`,
+	}, {
+		desc:         "extensions of repeated field contains null",
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "[pb2.ExtensionsContainer.rpt_ext_nested]": [
+    {"optString": "one"},
+    null,
+    {"optString": "three"}
+  ],
+}`,
+		wantErr: `(line 4:5): unexpected token null`,
+	}, {
+		desc:         "MessageSet",
+		inputMessage: &pb2.MessageSet{},
+		inputText: `{
+  "[pb2.MessageSetExtension]": {
+    "optString": "a messageset extension"
+  },
+  "[pb2.MessageSetExtension.ext_nested]": {
+    "optString": "just a regular extension"
+  },
+  "[pb2.MessageSetExtension.not_message_set_extension]": {
+    "optString": "not a messageset extension"
+  }
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.MessageSet{}
+			proto.SetExtension(m, pb2.E_MessageSetExtension_MessageSetExtension, &pb2.MessageSetExtension{
+				OptString: proto.String("a messageset extension"),
+			})
+			proto.SetExtension(m, pb2.E_MessageSetExtension_NotMessageSetExtension, &pb2.MessageSetExtension{
+				OptString: proto.String("not a messageset extension"),
+			})
+			proto.SetExtension(m, pb2.E_MessageSetExtension_ExtNested, &pb2.Nested{
+				OptString: proto.String("just a regular extension"),
+			})
+			return m
+		}(),
+		skip: true,
+	}, {
+		desc:         "not real MessageSet 1",
+		inputMessage: &pb2.FakeMessageSet{},
+		inputText: `{
+  "[pb2.FakeMessageSetExtension.message_set_extension]": {
+    "optString": "not a messageset extension"
+  }
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.FakeMessageSet{}
+			proto.SetExtension(m, pb2.E_FakeMessageSetExtension_MessageSetExtension, &pb2.FakeMessageSetExtension{
+				OptString: proto.String("not a messageset extension"),
+			})
+			return m
+		}(),
+		skip: true,
+	}, {
+		desc:         "not real MessageSet 2",
+		inputMessage: &pb2.FakeMessageSet{},
+		inputText: `{
+  "[pb2.FakeMessageSetExtension]": {
+    "optString": "not a messageset extension"
+  }
+}`,
+		wantErr: `unable to resolve "[pb2.FakeMessageSetExtension]": found wrong type`,
+		skip:    true,
+	}, {
+		desc:         "not real MessageSet 3",
+		inputMessage: &pb2.MessageSet{},
+		inputText: `{
+  "[pb2.message_set_extension]": {
+    "optString": "another not a messageset extension"
+  }
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.MessageSet{}
+			proto.SetExtension(m, pb2.E_MessageSetExtension, &pb2.FakeMessageSetExtension{
+				OptString: proto.String("another not a messageset extension"),
+			})
+			return m
+		}(),
+		skip: true,
 	}, {
 		desc:         "Empty",
 		inputMessage: &emptypb.Empty{},
@@ -850,6 +1516,25 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &wrapperspb.StringValue{},
 		inputText:    "\"abc\xff\"",
 		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "StringValue field with invalid UTF8 error",
+		inputMessage: &pb2.KnownTypes{},
+		inputText:    "{\n  \"optString\": \"abc\xff\"\n}",
+		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "NullValue field with JSON null",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optNull": null
+}`,
+		wantMessage: &pb2.KnownTypes{OptNull: new(structpb.NullValue)},
+	}, {
+		desc:         "NullValue field with string",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optNull": "NULL_VALUE"
+}`,
+		wantMessage: &pb2.KnownTypes{OptNull: new(structpb.NullValue)},
 	}, {
 		desc:         "BytesValue",
 		inputMessage: &wrapperspb.BytesValue{},
@@ -860,16 +1545,43 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &structpb.Value{},
 		inputText:    `null`,
 		wantMessage:  &structpb.Value{Kind: &structpb.Value_NullValue{}},
+	}, {
+		desc:         "Value field null",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": null
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptValue: &structpb.Value{Kind: &structpb.Value_NullValue{}},
+		},
 	}, {
 		desc:         "Value bool",
 		inputMessage: &structpb.Value{},
 		inputText:    `false`,
 		wantMessage:  &structpb.Value{Kind: &structpb.Value_BoolValue{}},
+	}, {
+		desc:         "Value field bool",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": true
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptValue: &structpb.Value{Kind: &structpb.Value_BoolValue{true}},
+		},
 	}, {
 		desc:         "Value number",
 		inputMessage: &structpb.Value{},
 		inputText:    `1.02`,
 		wantMessage:  &structpb.Value{Kind: &structpb.Value_NumberValue{1.02}},
+	}, {
+		desc:         "Value field number",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": 1.02
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptValue: &structpb.Value{Kind: &structpb.Value_NumberValue{1.02}},
+		},
 	}, {
 		desc:         "Value string",
 		inputMessage: &structpb.Value{},
@@ -880,6 +1592,22 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &structpb.Value{},
 		inputText:    "\"\xff\"",
 		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "Value field string",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": "NaN"
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptValue: &structpb.Value{Kind: &structpb.Value_StringValue{"NaN"}},
+		},
+	}, {
+		desc:         "Value field string with invalid UTF8",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": "` + "\xff" + `"
+}`,
+		wantErr: `invalid UTF-8`,
 	}, {
 		desc:         "Value empty struct",
 		inputMessage: &structpb.Value{},
@@ -931,6 +1659,25 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &structpb.Value{},
 		inputText:    "{\"string\": \"abc\xff\"}",
 		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "Value field struct",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": {
+    "string": "hello"
+  }
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptValue: &structpb.Value{
+				Kind: &structpb.Value_StructValue{
+					&structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"string": {Kind: &structpb.Value_StringValue{"hello"}},
+						},
+					},
+				},
+			},
+		},
 	}, {
 		desc:         "Value empty list",
 		inputMessage: &structpb.Value{},
@@ -986,6 +1733,13 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &structpb.Value{},
 		inputText:    "[\"abc\xff\"]",
 		wantErr:      `invalid UTF-8`,
+	}, {
+		desc:         "Value field list with invalid UTF8 string",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optValue": [ "` + "abc\xff" + `"]
+}`,
+		wantErr: `(line 2:17): invalid UTF-8`,
 	}, {
 		desc:         "Duration empty string",
 		inputMessage: &durationpb.Duration{},
@@ -1183,11 +1937,119 @@ func TestUnmarshal(t *testing.T) {
 		inputMessage: &fieldmaskpb.FieldMask{},
 		inputText:    `"foo@bar"`,
 		wantErr:      `google.protobuf.FieldMask.paths contains invalid path: "foo@bar"`,
+	}, {
+		desc:         "FieldMask field",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optFieldmask": "foo,qux.fooBar"
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptFieldmask: &fieldmaskpb.FieldMask{
+				Paths: []string{
+					"foo",
+					"qux.foo_bar",
+				},
+			},
+		},
 	}, {
 		desc:         "Any empty",
 		inputMessage: &anypb.Any{},
 		inputText:    `{}`,
 		wantMessage:  &anypb.Any{},
+	}, {
+		desc:         "Any with non-custom message",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "foo/pb2.Nested",
+  "optString": "embedded inside Any",
+  "optNested": {
+    "optString": "inception"
+  }
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Nested{
+				OptString: proto.String("embedded inside Any"),
+				OptNested: &pb2.Nested{
+					OptString: proto.String("inception"),
+				},
+			}
+			b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+			if err != nil {
+				t.Fatalf("error in binary marshaling message for Any.value: %v", err)
+			}
+			return &anypb.Any{
+				TypeUrl: "foo/pb2.Nested",
+				Value:   b,
+			}
+		}(),
+	}, {
+		desc:         "Any with empty embedded message",
+		inputMessage: &anypb.Any{},
+		inputText:    `{"@type": "foo/pb2.Nested"}`,
+		wantMessage:  &anypb.Any{TypeUrl: "foo/pb2.Nested"},
+	}, {
+		desc:         "Any without registered type",
+		umo:          protojson.UnmarshalOptions{Resolver: new(protoregistry.Types)},
+		inputMessage: &anypb.Any{},
+		inputText:    `{"@type": "foo/pb2.Nested"}`,
+		wantErr:      `(line 1:11): unable to resolve "foo/pb2.Nested":`,
+	}, {
+		desc:         "Any with missing required",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "pb2.PartialRequired",
+  "optString": "embedded inside Any"
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.PartialRequired{
+				OptString: proto.String("embedded inside Any"),
+			}
+			b, err := proto.MarshalOptions{
+				Deterministic: true,
+				AllowPartial:  true,
+			}.Marshal(m)
+			if err != nil {
+				t.Fatalf("error in binary marshaling message for Any.value: %v", err)
+			}
+			return &anypb.Any{
+				TypeUrl: string(m.ProtoReflect().Descriptor().FullName()),
+				Value:   b,
+			}
+		}(),
+	}, {
+		desc: "Any with partial required and AllowPartial",
+		umo: protojson.UnmarshalOptions{
+			AllowPartial: true,
+		},
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "pb2.PartialRequired",
+  "optString": "embedded inside Any"
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.PartialRequired{
+				OptString: proto.String("embedded inside Any"),
+			}
+			b, err := proto.MarshalOptions{
+				Deterministic: true,
+				AllowPartial:  true,
+			}.Marshal(m)
+			if err != nil {
+				t.Fatalf("error in binary marshaling message for Any.value: %v", err)
+			}
+			return &anypb.Any{
+				TypeUrl: string(m.ProtoReflect().Descriptor().FullName()),
+				Value:   b,
+			}
+		}(),
+	}, {
+		desc:         "Any with invalid UTF8",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "optString": "` + "abc\xff" + `",
+  "@type": "foo/pb2.Nested"
+}`,
+		wantErr: `(line 2:16): invalid UTF-8`,
 	}, {
 		desc:         "Any with BoolValue",
 		inputMessage: &anypb.Any{},
@@ -1364,6 +2226,15 @@ func TestUnmarshal(t *testing.T) {
   "@type": ""
 }`,
 		wantErr: `(line 2:12): @type field contains empty value`,
+	}, {
+		desc:         "Any with duplicate @type",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "google.protobuf.StringValue",
+  "value": "hello",
+  "@type": "pb2.Nested"
+}`,
+		wantErr: `(line 4:3): duplicate "@type" field`,
 	}, {
 		desc:         "Any with duplicate value",
 		inputMessage: &anypb.Any{},
@@ -1373,6 +2244,102 @@ func TestUnmarshal(t *testing.T) {
   "value": "world"
 }`,
 		wantErr: `(line 4:3): duplicate "value" field`,
+	}, {
+		desc:         "Any with unknown field",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "pb2.Nested",
+  "optString": "hello",
+  "unknown": "world"
+}`,
+		wantErr: `(line 4:3): unknown field "unknown"`,
+	}, {
+		desc:         "Any with embedded type containing Any",
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "pb2.KnownTypes",
+  "optAny": {
+    "@type": "google.protobuf.StringValue",
+    "value": "` + "abc\xff" + `"
+  }
+}`,
+		wantErr: `(line 5:14): invalid UTF-8`,
+	}, {
+		desc:         "well known types as field values",
+		inputMessage: &pb2.KnownTypes{},
+		inputText: `{
+  "optBool": false,
+  "optInt32": 42,
+  "optInt64": "42",
+  "optUint32": 42,
+  "optUint64": "42",
+  "optFloat": 1.23,
+  "optDouble": 3.1415,
+  "optString": "hello",
+  "optBytes": "aGVsbG8=",
+  "optDuration": "123s",
+  "optTimestamp": "2019-03-19T23:03:21Z",
+  "optStruct": {
+    "string": "hello"
+  },
+  "optList": [
+    null,
+    "",
+    {},
+    []
+  ],
+  "optValue": "world",
+  "optEmpty": {},
+  "optAny": {
+    "@type": "google.protobuf.Empty",
+    "value": {}
+  },
+  "optFieldmask": "fooBar,barFoo"
+}`,
+		wantMessage: &pb2.KnownTypes{
+			OptBool:      &wrapperspb.BoolValue{Value: false},
+			OptInt32:     &wrapperspb.Int32Value{Value: 42},
+			OptInt64:     &wrapperspb.Int64Value{Value: 42},
+			OptUint32:    &wrapperspb.UInt32Value{Value: 42},
+			OptUint64:    &wrapperspb.UInt64Value{Value: 42},
+			OptFloat:     &wrapperspb.FloatValue{Value: 1.23},
+			OptDouble:    &wrapperspb.DoubleValue{Value: 3.1415},
+			OptString:    &wrapperspb.StringValue{Value: "hello"},
+			OptBytes:     &wrapperspb.BytesValue{Value: []byte("hello")},
+			OptDuration:  &durationpb.Duration{Seconds: 123},
+			OptTimestamp: &timestamppb.Timestamp{Seconds: 1553036601},
+			OptStruct: &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					"string": {Kind: &structpb.Value_StringValue{"hello"}},
+				},
+			},
+			OptList: &structpb.ListValue{
+				Values: []*structpb.Value{
+					{Kind: &structpb.Value_NullValue{}},
+					{Kind: &structpb.Value_StringValue{}},
+					{
+						Kind: &structpb.Value_StructValue{
+							&structpb.Struct{Fields: map[string]*structpb.Value{}},
+						},
+					},
+					{
+						Kind: &structpb.Value_ListValue{
+							&structpb.ListValue{Values: []*structpb.Value{}},
+						},
+					},
+				},
+			},
+			OptValue: &structpb.Value{
+				Kind: &structpb.Value_StringValue{"world"},
+			},
+			OptEmpty: &emptypb.Empty{},
+			OptAny: &anypb.Any{
+				TypeUrl: "google.protobuf.Empty",
+			},
+			OptFieldmask: &fieldmaskpb.FieldMask{
+				Paths: []string{"foo_bar", "bar_foo"},
+			},
+		},
 	}, {
 		desc:         "DiscardUnknown: regular messages",
 		umo:          protojson.UnmarshalOptions{DiscardUnknown: true},
@@ -1387,6 +2354,22 @@ func TestUnmarshal(t *testing.T) {
   "unknown": "not known"
 }`,
 		wantMessage: &pb3.Nests{SNested: &pb3.Nested{}},
+	}, {
+		desc:         "DiscardUnknown: repeated",
+		umo:          protojson.UnmarshalOptions{DiscardUnknown: true},
+		inputMessage: &pb2.Nests{},
+		inputText: `{
+  "rptNested": [
+    {"unknown": "blah"},
+	{"optString": "hello"}
+  ]
+}`,
+		wantMessage: &pb2.Nests{
+			RptNested: []*pb2.Nested{
+				{},
+				{OptString: proto.String("hello")},
+			},
+		},
 	}, {
 		desc:         "DiscardUnknown: map",
 		umo:          protojson.UnmarshalOptions{DiscardUnknown: true},
@@ -1403,6 +2386,20 @@ func TestUnmarshal(t *testing.T) {
 				"nested_one": {},
 			},
 		},
+	}, {
+		desc:         "DiscardUnknown: extension",
+		umo:          protojson.UnmarshalOptions{DiscardUnknown: true},
+		inputMessage: &pb2.Extensions{},
+		inputText: `{
+  "[pb2.opt_ext_nested]": {
+	"unknown": []
+  }
+}`,
+		wantMessage: func() proto.Message {
+			m := &pb2.Extensions{}
+			proto.SetExtension(m, pb2.E_OptExtNested, &pb2.Nested{})
+			return m
+		}(),
 	}, {
 		desc:         "DiscardUnknown: Empty",
 		umo:          protojson.UnmarshalOptions{DiscardUnknown: true},
@@ -1418,6 +2415,19 @@ func TestUnmarshal(t *testing.T) {
   "unknown": true
 }`,
 		wantMessage: &anypb.Any{},
+	}, {
+		desc: "DiscardUnknown: Any",
+		umo: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+		inputMessage: &anypb.Any{},
+		inputText: `{
+  "@type": "foo/pb2.Nested",
+  "unknown": "none"
+}`,
+		wantMessage: &anypb.Any{
+			TypeUrl: "foo/pb2.Nested",
+		},
 	}, {
 		desc: "DiscardUnknown: Any with Empty",
 		umo: protojson.UnmarshalOptions{
@@ -1443,6 +2453,10 @@ func TestUnmarshal(t *testing.T) {
 	[FuzzyDecode] double string with trailing space
 	[FuzzyDecode] enum set to number string
 	[FuzzyDecode] map contains null for message value
+	[FuzzyDecode] not string
+	[FuzzyDecode] null message
+	[FuzzyDecode] repeated scalars contain invalid type
+	[FuzzyDecode] repeated messages contain invalid type
 	[ErrMsgNotSame] string with invalid UTF-8
 	[ErrMsgNotSame] camelCase name
 	[ErrMsgNotSame] message set to non-message
@@ -1460,12 +2474,20 @@ func TestUnmarshal(t *testing.T) {
 	[ErrMsgNotSame] Any with missing @type
 	[ErrMsgNotSame] Any with empty @type
 	[ErrMsgNotSame] Any with duplicate value
-	[NotSupport] proto name and json_name
-	[NotSupport] duplicate field names
-	[NotSupport] oneof set to more than one field
-	[NotSupport] oneof set to null and value
-	[NotSupport] map contains duplicate keys
-	[NotSupport] DiscardUnknown: Any without type
+	[ErrMsgNotSame] unexpected value instead of EOF
+	[ErrMsgNotSame] invalid extension field name
+	[ErrMsgNotSame] extensions of repeated field contains null
+	[ErrMsgNotSame] Value field list with invalid UTF8 string
+	[ErrMsgNotSame] Any without registered type
+	[ErrMsgNotSame] Any with invalid UTF8
+	[ErrMsgNotSame] Any with duplicate @type
+	[ErrMsgNotSame] Any with unknown field
+	[ErrMsgNotSame] Any with embedded type containing Any
+	[ErrMsgNotSame] proto name and json_name
+	[ErrMsgNotSame] duplicate field names
+	[ErrMsgNotSame] oneof set to more than one field
+	[ErrMsgNotSame] map contains duplicate keys
+	[CheckRequiredInAny] Any with missing required
 	`
 	
 	ignoreDescs := map[string]string{}
@@ -1485,37 +2507,57 @@ func TestUnmarshal(t *testing.T) {
 			continue
 		}
 
-		if sign,ok := ignoreDescs[tt.desc];ok {
-			switch sign {
-			case "FuzzyDecode","NotSupport","ErrMsgNotSame":
-				continue
-			}
+		sign := ignoreDescs[tt.desc]
+		switch sign {
+		case "CheckRequiredInAny":
+			continue
 		}
 
-		t.Run(tt.desc, func(t *testing.T) {
-			cfg := jsoniter.Config{SortMapKeys: true,DisallowUnknownFields: !tt.umo.DiscardUnknown}.Froze()
-			cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: tt.umo.Resolver})
-			err := cfg.Unmarshal([]byte(tt.inputText), tt.inputMessage)
-			if err != nil {
-				if tt.wantErr == "" {
-					t.Errorf("Unmarshal() got unexpected error: %v", err)
-				} else {
-					if strings.Contains(tt.wantErr, "invalid value for") {
+		// the input accepted by the fuzzy decode is only rejected with Strict,
+		// and the error messages are checked with ProtojsonErrors, or if they are the same as protojson
+		for _, strict := range []bool{false, true} {
+			for _, protojsonErrors := range []bool{false, true} {
+				strict, protojsonErrors := strict, protojsonErrors
+				if sign == "FuzzyDecode" && !strict {
+					continue
+				}
+				desc := tt.desc
+				if protojsonErrors {
+					desc = "ProtojsonErrors/" + desc
+				}
+				if strict {
+					desc = "Strict/" + desc
+				}
+				t.Run(desc, func(t *testing.T) {
+					err := jsoniterpb.UnmarshalOptions{
+						DiscardUnknown:  tt.umo.DiscardUnknown,
+						Resolver:        tt.umo.Resolver,
+						AllowPartial:    tt.umo.AllowPartial,
+						ProtojsonErrors: protojsonErrors,
+						Strict:          strict,
+					}.Unmarshal([]byte(tt.inputText), tt.inputMessage)
+					if err != nil {
+						if tt.wantErr == "" {
+							t.Errorf("Unmarshal() got unexpected error: %v", err)
+						} else {
+							if !protojsonErrors && (strings.Contains(tt.wantErr, "invalid value for") || sign == "ErrMsgNotSame" || sign == "FuzzyDecode") {
+								return
+							}
+							if !strings.Contains(err.Error(), tt.wantErr) {
+								t.Errorf("Unmarshal() error got %q, want %q", err, tt.wantErr)
+							}
+						} 
 						return
 					}
-					if !strings.Contains(err.Error(), tt.wantErr) {
-						t.Errorf("Unmarshal() error got %q, want %q", err, tt.wantErr)
+					if tt.wantErr != "" {
+						t.Errorf("Unmarshal() got nil error, want error %q", tt.wantErr)
 					}
-				} 
-				return
-			}
-			if tt.wantErr != "" {
-				t.Errorf("Unmarshal() got nil error, want error %q", tt.wantErr)
-			}
-			if tt.wantMessage != nil && !proto.Equal(tt.inputMessage, tt.wantMessage) {
-				t.Errorf("Unmarshal()\n<got>\n%v\n<want>\n%v\n", tt.inputMessage, tt.wantMessage)
+					if tt.wantMessage != nil && !proto.Equal(tt.inputMessage, tt.wantMessage) {
+						t.Errorf("Unmarshal()\n<got>\n%v\n<want>\n%v\n", tt.inputMessage, tt.wantMessage)
+					}
+				})
 			}
-		})
+		}
 	}
 }	
 
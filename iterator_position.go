@@ -0,0 +1,52 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// The offsets of Error and the checks which report the position of a value need the position of the iterator in its input,
+// which is not exported by the pinned version of jsoniter, so it is read by reflection.
+// The fields are looked up by their names and types once, and the package panics when it is initialized if any of them is missing,
+// so that a change of jsoniter is found at once instead of making the offsets unknown quietly.
+
+var iteratorFieldIndexes = func() (indexes struct{ head, buf, reader int }) {
+	typ := reflect.TypeOf(jsoniter.Iterator{})
+	for _, f := range []struct {
+		name  string
+		typ   reflect.Type
+		index *int
+	}{
+		{"head", reflect.TypeOf(0), &indexes.head},
+		{"buf", reflect.TypeOf([]byte(nil)), &indexes.buf},
+		{"reader", reflect.TypeOf((*io.Reader)(nil)).Elem(), &indexes.reader},
+	} {
+		field, ok := typ.FieldByName(f.name)
+		if !ok || field.Type != f.typ || len(field.Index) != 1 {
+			panic(fmt.Sprintf("jsoniterpb: jsoniter.Iterator has no field %s of %v", f.name, f.typ))
+		}
+		*f.index = field.Index[0]
+	}
+	return
+}()
+
+// iterOffset returns the offset of the next byte to read in the input of iter, -1 if iter reads from an io.Reader
+func iterOffset(iter *jsoniter.Iterator) int {
+	v := reflect.ValueOf(iter).Elem()
+	if !v.Field(iteratorFieldIndexes.reader).IsNil() {
+		return -1
+	}
+	return int(v.Field(iteratorFieldIndexes.head).Int())
+}
+
+// iterInput returns the input of iter, nil if iter reads from an io.Reader
+func iterInput(iter *jsoniter.Iterator) []byte {
+	v := reflect.ValueOf(iter).Elem()
+	if !v.Field(iteratorFieldIndexes.reader).IsNil() {
+		return nil
+	}
+	return v.Field(iteratorFieldIndexes.buf).Bytes()
+}
@@ -48,6 +48,26 @@ func (e *ProtoExtension) decorateEncoderForNilCollection(typ reflect2.Type, enco
 	return nil
 }
 
+var bytesType = reflect2.TypeOf([]byte(nil))
+
+// - marshal nil []byte of proto2 to null, it means unset
+// - omit it if nil when omitempty
+type protoPresenceBytesEncoder struct {
+	jsoniter.ValEncoder
+}
+
+func (enc *protoPresenceBytesEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if *((*[]byte)(ptr)) == nil {
+		stream.WriteNil()
+		return
+	}
+	enc.ValEncoder.Encode(ptr, stream)
+}
+
+func (enc *protoPresenceBytesEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return *((*[]byte)(ptr)) == nil
+}
+
 var wktValuePtrType = reflect2.TypeOfPtr((*structpb.Value)(nil))
 
 func (e *ProtoExtension) decorateDecoderForNil(typ reflect2.Type, dec jsoniter.ValDecoder) jsoniter.ValDecoder {
@@ -1,8 +1,6 @@
 package jsoniterpb
 
 import (
-	"fmt"
-	"io"
 	"reflect"
 	"strings"
 	"unsafe"
@@ -58,10 +56,19 @@ func (e *ProtoExtension) updateStructDescriptorConstructorForOneOf(c *jsoniter.S
 								structDescriptor := c.DescribeStructFunc(wrapPtrType.Elem())
 								for _, b := range structDescriptor.Fields {
 									b.Levels = append([]int{binding.Levels[0], j}, b.Levels...)
+									if e.isIgnoredField(fd) {
+										continue
+									}
+									e.applyCustomFieldNames(b, fd)
 									omitempty := b.Encoder.(*jsoniter.StructFieldEncoder).OmitEmpty
-									b.Encoder = &protoOneofWrapperEncoder{wrapPtrType, b.Field, b.Encoder}
+									b.Encoder = e.wrapStructFieldEncoderForInt64AsNumber(fd, b.Field, b.Encoder)
+									name := b.Field.Name()
+									if len(b.ToNames) > 0 {
+										name = b.ToNames[0]
+									}
+									b.Encoder = &protoOneofWrapperEncoder{wrapPtrType, b.Field, name, fd, b.Encoder}
 									b.Encoder = &jsoniter.StructFieldEncoder{field, b.Encoder, omitempty}
-									b.Decoder = &protoOneofWrapperDecoder{field.Type(), wrapPtrType, wrapPtrType.Elem(), b.Field, b.Decoder}
+									b.Decoder = &protoOneofWrapperDecoder{field.Type(), wrapPtrType, wrapPtrType.Elem(), b.Field, name, fd, b.Decoder}
 									b.Decoder = &jsoniter.StructFieldDecoder{field, b.Decoder}
 									c.EmbeddedBindings = append(c.EmbeddedBindings, b)
 								}
@@ -130,10 +137,15 @@ func (enc *protoOptionalEncoder) IsEmpty(ptr unsafe.Pointer) bool {
 type protoOneofWrapperEncoder struct {
 	wrapperPtrType reflect2.Type
 	valueField     reflect2.StructField
+	name           string
+	fd             protoreflect.FieldDescriptor
 	valueEncoder   jsoniter.ValEncoder
 }
 
 func (encoder *protoOneofWrapperEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if hasStreamError(stream) {
+		return
+	}
 	if *((*unsafe.Pointer)(ptr)) == nil {
 		stream.WriteNil()
 		return
@@ -144,8 +156,9 @@ func (encoder *protoOneofWrapperEncoder) Encode(ptr unsafe.Pointer, stream *json
 		return
 	}
 	encoder.valueEncoder.Encode(reflect2.PtrOf(val), stream)
-	if stream.Error != nil && stream.Error != io.EOF {
-		stream.Error = fmt.Errorf("%s: %s", encoder.valueField.Name(), stream.Error.Error())
+	if e := streamError(stream); e != nil {
+		e.prependField(encoder.name, encoder.fd)
+		setStreamError(stream, e)
 	}
 }
 
@@ -180,10 +193,24 @@ type protoOneofWrapperDecoder struct {
 	wrapperPtrType   reflect2.Type
 	wrapperElemType  reflect2.Type
 	valueField       reflect2.StructField
+	name             string
+	fd               protoreflect.FieldDescriptor
 	valueDecoder     jsoniter.ValDecoder
 }
 
 func (decoder *protoOneofWrapperDecoder) Decode(fieldPtr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if !checkDuplicateField(iter, decoder.fd) {
+		return
+	}
+	// null means unset, except for google.protobuf.Value and google.protobuf.NullValue
+	if iter.WhatIsNext() == jsoniter.NilValue {
+		valueType := decoder.valueField.Type()
+		if valueType != wktValuePtrType && valueType != nullValuePtrType.(reflect2.PtrType).Elem() {
+			iter.Skip()
+			return
+		}
+	}
+
 	var elem interface{}
 
 	// reuse it if type match
@@ -197,9 +224,19 @@ func (decoder *protoOneofWrapperDecoder) Decode(fieldPtr unsafe.Pointer, iter *j
 		elem = decoder.wrapperElemType.New()
 	}
 
+	if c := fuzzyDecodeCollectorOf(iter); c != nil {
+		c.pushField(decoder.name, decoder.fd)
+		defer c.pop()
+	}
+	takeUnknownEnum(iter)
 	decoder.valueDecoder.Decode(reflect2.PtrOf(elem), iter)
-	if iter.Error != nil && iter.Error != io.EOF {
-		iter.Error = fmt.Errorf("%s: %s", decoder.valueField.Name(), iter.Error.Error())
+	if e := iterError(iter); e != nil {
+		e.prependField(decoder.name, decoder.fd)
+		setIterError(iter, e)
+		return
+	}
+	// the member of an unknown enum name is left unset
+	if takeUnknownEnum(iter) {
 		return
 	}
 
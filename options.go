@@ -0,0 +1,210 @@
+package jsoniterpb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// The options below mirror the ones of protojson, so that migrating from protojson is just an import change.
+// Each option set shares a cached frozen jsoniter.API, which is keyed by the options without Resolver,
+// and Resolver is carried by the state of each call, see resolverOf.
+
+// Marshal writes the given proto.Message in JSON format using default options.
+func Marshal(m proto.Message) ([]byte, error) {
+	return MarshalOptions{}.Marshal(m)
+}
+
+// Unmarshal reads the given []byte into the given proto.Message using default options.
+func Unmarshal(b []byte, m proto.Message) error {
+	return UnmarshalOptions{}.Unmarshal(b, m)
+}
+
+// Format formats the message as a multiline string.
+func Format(m proto.Message) string {
+	return MarshalOptions{Multiline: true}.Format(m)
+}
+
+// MarshalOptions is a configurable JSON format marshaler, the same as protojson.MarshalOptions.
+type MarshalOptions struct {
+	// Multiline specifies whether the marshaler should format the output in
+	// indented-form with every textual element on a new line.
+	// If Indent is an empty string, then two spaces are used.
+	Multiline bool
+	// Indent specifies the set of indentation characters to use in a multiline
+	// formatted output, it can only be composed of space or tab characters.
+	// If non-empty, then Multiline is treated as being set.
+	Indent string
+
+	AllowPartial    bool
+	UseProtoNames   bool
+	UseEnumNumbers  bool
+	EmitUnpopulated bool
+	// EmitDefaultValues emits the zero values of the fields without presence, see ProtoExtension.EmitDefaultValues.
+	EmitDefaultValues bool
+	Resolver          interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+}
+
+// Format returns the formatted string of m, errors are ignored.
+func (o MarshalOptions) Format(m proto.Message) string {
+	if m == nil || reflect2.IsNil(m) {
+		return "<nil>"
+	}
+	o.AllowPartial = true
+	b, _ := o.Marshal(m)
+	return string(b)
+}
+
+// Marshal marshals the given proto.Message in the JSON format using options in MarshalOptions.
+// The nil message is marshaled to an empty JSON object, which is the same as protojson.
+func (o MarshalOptions) Marshal(m proto.Message) ([]byte, error) {
+	indent := o.Indent
+	if indent == "" && o.Multiline {
+		indent = "  "
+	}
+	if strings.Trim(indent, " \t") != "" {
+		return nil, errors.New("indent may only be composed of space or tab characters")
+	}
+
+	if m == nil || reflect2.IsNil(m) {
+		return []byte("{}"), nil
+	}
+
+	// IndentionStep of jsoniter only supports spaces, others are formatted after marshaling
+	o.Multiline = false
+	o.Indent = indent
+	if strings.Contains(indent, "\t") {
+		o.Indent = ""
+	}
+	b, err := marshal(o.api(), o.Resolver, m)
+	if err != nil {
+		return nil, err
+	}
+	if o.Indent == indent {
+		return b, nil
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", indent); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func (o MarshalOptions) api() jsoniter.API {
+	o.Resolver = nil
+	return loadOrFreezeAPI(o, func() jsoniter.API {
+		cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: true, IndentionStep: len(o.Indent)}.Froze()
+		cfg.RegisterExtension(&ProtoExtension{
+			EmitUnpopulated:   o.EmitUnpopulated,
+			EmitDefaultValues: o.EmitDefaultValues,
+			UseEnumNumbers:    o.UseEnumNumbers,
+			UseProtoNames:     o.UseProtoNames,
+			AllowPartial:      o.AllowPartial,
+		})
+		return cfg
+	})
+}
+
+// UnmarshalOptions is a configurable JSON format parser, the same as protojson.UnmarshalOptions.
+type UnmarshalOptions struct {
+	AllowPartial bool
+	// DiscardUnknown ignores the unknown fields, it also ignores google.protobuf.Any whose "@type" is missing or can not be resolved,
+	// which is different from protojson, which fails if the type can not be resolved.
+	DiscardUnknown bool
+	Resolver       interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+
+	// ProtojsonErrors makes the messages of errors the same as protojson, see ProtoExtension.ProtojsonErrors.
+	ProtojsonErrors bool
+	// Strict accepts exactly the input protojson accepts, see ProtoExtension.Strict.
+	Strict bool
+}
+
+// Unmarshal reads the given []byte and populates the given proto.Message using options in UnmarshalOptions.
+// It will clear the message first before setting the fields, which is the same as protojson.
+// The returned *Error has Line and Column of the failure in b.
+func (o UnmarshalOptions) Unmarshal(b []byte, m proto.Message) error {
+	proto.Reset(m)
+	err := unmarshal(o.api(), o.Resolver, b, m)
+	if err != nil && o.ProtojsonErrors {
+		err = o.protojsonError(b, m, err)
+	}
+	var perr *Error
+	if errors.As(err, &perr) {
+		perr.locate(b)
+	}
+	return err
+}
+
+func (o UnmarshalOptions) api() jsoniter.API {
+	o.Resolver = nil
+	return loadOrFreezeAPI(o, func() jsoniter.API {
+		cfg := jsoniter.Config{SortMapKeys: true, DisallowUnknownFields: !o.DiscardUnknown, CaseSensitive: o.Strict}.Froze()
+		cfg.RegisterExtension(&ProtoExtension{
+			AllowPartial:    o.AllowPartial,
+			DuplicateFields: DuplicateFieldsDisallow,
+			ProtojsonErrors: o.ProtojsonErrors,
+			Strict:          o.Strict,
+		})
+		return cfg
+	})
+}
+
+var frozenAPIs sync.Map
+
+func loadOrFreezeAPI(key interface{}, freeze func() jsoniter.API) jsoniter.API {
+	if api, ok := frozenAPIs.Load(key); ok {
+		return api.(jsoniter.API)
+	}
+	api, _ := frozenAPIs.LoadOrStore(key, freeze())
+	return api.(jsoniter.API)
+}
+
+// marshal is the same as Marshal of api, except that resolver is given to the call
+func marshal(api jsoniter.API, resolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}, v interface{}) ([]byte, error) {
+	stream := api.BorrowStream(nil)
+	defer api.ReturnStream(stream)
+	st := &callState{resolver: resolver}
+	stream.Attachment = st
+	stream.WriteVal(v)
+	if e := streamError(stream); e != nil {
+		return nil, e
+	}
+	result := stream.Buffer()
+	copied := make([]byte, len(result))
+	copy(copied, result)
+	return copied, nil
+}
+
+// unmarshal is the same as Unmarshal of api, except that resolver is given to the call
+func unmarshal(api jsoniter.API, resolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}, data []byte, v interface{}) error {
+	iter := api.BorrowIterator(data)
+	defer api.ReturnIterator(iter)
+	iter.Attachment = &callState{input: data, resolver: resolver}
+	iter.ReadVal(v)
+	if c := iter.NextToken(); c != 0 {
+		reportSyntaxError(iter, "Unmarshal", "there are bytes left after unmarshal")
+	}
+	if e := iterError(iter); e != nil {
+		return e
+	}
+	return nil
+}
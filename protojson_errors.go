@@ -0,0 +1,314 @@
+package jsoniterpb
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// With ProtojsonErrors, the message of an error is the one protojson returns for the failed message,
+// which is found by unmarshaling the message again with protojson, on the failure only.
+// It is done by the innermost message which is read from the input of the call,
+// the bytes before the message are replaced with spaces, so that "(line L:C)" is the position in the whole input.
+// Since protojson may fail at another position, e.g. the key of an unknown field instead of its value,
+// or at another value which jsoniterpb accepts, Path, FieldName and Offset are moved to the position of protojson.
+// The error of jsoniterpb is kept if protojson accepts the message or the input is read from an io.Reader.
+
+func (e *ProtoExtension) decorateDecoderForProtojsonErrors(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if !e.ProtojsonErrors {
+		return nil
+	}
+	if typ.Kind() != reflect.Struct || !reflect2.PtrTo(typ).Implements(protoMessageType) {
+		return nil
+	}
+	return &protojsonErrorsDecoder{
+		ext:          e,
+		valueType:    typ,
+		valueDecoder: decoder,
+	}
+}
+
+type protojsonErrorsDecoder struct {
+	ext          *ProtoExtension
+	valueType    reflect2.Type
+	valueDecoder jsoniter.ValDecoder
+
+	once           sync.Once
+	discardUnknown bool
+}
+
+func (dec *protojsonErrorsDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	dec.once.Do(func() {
+		if fcfg, ok := iter.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok {
+			dec.discardUnknown = !fcfg.GetConfig().DisallowUnknownFields
+		}
+	})
+	st, ok := enterIterCall(iter)
+	if ok {
+		defer leaveIterCall(iter, st)
+	}
+	if hasIterError(iter) {
+		dec.valueDecoder.Decode(ptr, iter)
+		return
+	}
+
+	iter.WhatIsNext()
+	start := iterOffset(iter)
+	dec.valueDecoder.Decode(ptr, iter)
+	e := iterError(iter)
+	if e == nil || e.protojson || start < 0 {
+		return
+	}
+	// the messages inside google.protobuf.Any are read from the buffers rebuilt from the input, which are done by the message containing the Any
+	input := iterInput(iter)
+	if !isSameInput(input, st.input) {
+		return
+	}
+
+	opts := protojson.UnmarshalOptions{
+		AllowPartial:   dec.ext.AllowPartial,
+		DiscardUnknown: dec.discardUnknown,
+		Resolver:       dec.ext.resolverOf(iter.Attachment),
+	}
+	value := input[start:]
+	subIter := jsoniter.ConfigDefault.BorrowIterator(value)
+	if b := subIter.SkipAndReturnBytes(); subIter.Error == nil {
+		value = b
+	}
+	jsoniter.ConfigDefault.ReturnIterator(subIter)
+	m := dec.valueType.PackEFace(ptr).(proto.Message)
+	if dec.ext.replaceWithProtojsonError(e, opts, input, start, value, m) {
+		setIterError(iter, e)
+	}
+}
+
+// isSameInput reports whether a and b are the same slice
+func isSameInput(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || &a[0] == &b[0]
+}
+
+// protojsonError replaces the error of UnmarshalOptions with the one of protojson, e.g. for the bytes after the message
+func (o UnmarshalOptions) protojsonError(b []byte, m proto.Message, err error) error {
+	var perr *Error
+	if !errors.As(err, &perr) {
+		perr = &Error{Offset: -1, Err: err}
+	}
+	if perr.protojson {
+		return err
+	}
+	opts := protojson.UnmarshalOptions{
+		AllowPartial:   o.AllowPartial,
+		DiscardUnknown: o.DiscardUnknown,
+		Resolver:       o.Resolver,
+	}
+	if !(&ProtoExtension{}).replaceWithProtojsonError(perr, opts, b, 0, b, m) {
+		return err
+	}
+	return perr
+}
+
+// replaceWithProtojsonError sets the error of protojson for the message m to perr, which is value starting at start of input,
+// it returns false if protojson accepts the message.
+// The path of perr is relative to m, so that the fields containing m prepend their names later.
+func (e *ProtoExtension) replaceWithProtojsonError(perr *Error, opts protojson.UnmarshalOptions, input []byte, start int, value []byte, m proto.Message) bool {
+	// each rune is replaced, since the column of protojson is counted in runes
+	b := make([]byte, 0, start+len(value))
+	for _, c := range string(input[:start]) {
+		if c == '\n' {
+			b = append(b, '\n')
+		} else {
+			b = append(b, ' ')
+		}
+	}
+	b = append(b, value...)
+	pjerr := opts.Unmarshal(b, m.ProtoReflect().New().Interface())
+	if pjerr == nil {
+		return false
+	}
+
+	perr.Err = pjerr
+	perr.protojson = true
+	if offset := protojsonErrorOffset(input, pjerr); offset >= start && offset != perr.Offset {
+		located := e.protojsonErrorPath(input[start:], offset-start, m.ProtoReflect().Descriptor())
+		perr.Path, perr.FieldName, perr.Offset = located.Path, located.FieldName, offset
+	}
+	return true
+}
+
+var protojsonPositionRegexp = regexp.MustCompile(`\(line (\d+):(\d+)\)`)
+
+// protojsonErrorOffset returns the offset in input of "(line L:C)" in the error of protojson, -1 if there is none
+func protojsonErrorOffset(input []byte, err error) int {
+	match := protojsonPositionRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return -1
+	}
+	line, _ := strconv.Atoi(match[1])
+	column, _ := strconv.Atoi(match[2])
+	offset := 0
+	for ; line > 1; line-- {
+		i := bytes.IndexByte(input[offset:], '\n')
+		if i < 0 {
+			return -1
+		}
+		offset += i + 1
+	}
+	for ; column > 1 && offset < len(input) && input[offset] != '\n'; column-- {
+		_, size := utf8.DecodeRune(input[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// protojsonErrorPath returns the error with Path and FieldName of the value at offset of input, which is a message of md
+func (e *ProtoExtension) protojsonErrorPath(input []byte, offset int, md protoreflect.MessageDescriptor) *Error {
+	iter := jsoniter.ConfigDefault.BorrowIterator(input)
+	defer jsoniter.ConfigDefault.ReturnIterator(iter)
+	p := &protojsonPathFinder{ext: e, target: offset}
+	p.find(iter, jsonValueOf(md))
+
+	perr := &Error{}
+	for i := len(p.segments) - 1; i >= 0; i-- {
+		seg := p.segments[i]
+		switch {
+		case seg.index >= 0:
+			perr.prependIndex(seg.index)
+		case seg.isKey:
+			perr.prependKey(seg.name)
+		default:
+			perr.prependField(seg.name, seg.fd)
+		}
+	}
+	return perr
+}
+
+// jsonValue tells what a JSON value is in the message:
+// the fields of md for an object, the elements of the list field or the entries of the map field fd, or the well-known types of google.protobuf.Struct
+type jsonValue struct {
+	md       protoreflect.MessageDescriptor
+	fd       protoreflect.FieldDescriptor
+	isStruct bool
+}
+
+var (
+	wktStructFullName    = (&structpb.Struct{}).ProtoReflect().Descriptor().FullName()
+	wktValueFullName     = (&structpb.Value{}).ProtoReflect().Descriptor().FullName()
+	wktListValueFullName = (&structpb.ListValue{}).ProtoReflect().Descriptor().FullName()
+)
+
+func jsonValueOf(md protoreflect.MessageDescriptor) jsonValue {
+	if md == nil {
+		return jsonValue{}
+	}
+	switch md.FullName() {
+	case wktStructFullName, wktValueFullName, wktListValueFullName:
+		return jsonValue{isStruct: true}
+	}
+	return jsonValue{md: md}
+}
+
+// fieldValue returns the value of fd, or the value of its element if isElem
+func fieldValue(fd protoreflect.FieldDescriptor, isElem bool) jsonValue {
+	switch {
+	case fd == nil:
+		return jsonValue{}
+	case !isElem && (fd.IsList() || fd.IsMap()):
+		return jsonValue{fd: fd}
+	case fd.IsMap():
+		return fieldValue(fd.MapValue(), false)
+	}
+	return jsonValueOf(fd.Message())
+}
+
+type jsonPathSegment struct {
+	name  string
+	fd    protoreflect.FieldDescriptor
+	isKey bool
+	index int
+}
+
+// protojsonPathFinder finds the path of the value at target by reading the input until target
+type protojsonPathFinder struct {
+	ext      *ProtoExtension
+	target   int
+	segments []jsonPathSegment
+}
+
+// find reads the value of v, it returns true if target is in the value, then segments is the path to target
+func (p *protojsonPathFinder) find(iter *jsoniter.Iterator, v jsonValue) bool {
+	next := iter.WhatIsNext()
+	if hasIterError(iter) || iterOffset(iter) >= p.target {
+		return true
+	}
+	switch next {
+	case jsoniter.ObjectValue:
+		found := false
+		iter.ReadMapCB(func(iter *jsoniter.Iterator, key string) bool {
+			seg, child := p.objectEntry(v, key)
+			p.segments = append(p.segments, seg)
+			if iterOffset(iter) > p.target || p.find(iter, child) {
+				found = true
+				return false
+			}
+			p.segments = p.segments[:len(p.segments)-1]
+			return true
+		})
+		if found {
+			return true
+		}
+	case jsoniter.ArrayValue:
+		for i := 0; iter.ReadArray(); i++ {
+			p.segments = append(p.segments, jsonPathSegment{index: i})
+			if p.find(iter, p.arrayElement(v)) {
+				return true
+			}
+			p.segments = p.segments[:len(p.segments)-1]
+		}
+	default:
+		iter.Skip()
+	}
+	return hasIterError(iter) || iterOffset(iter) > p.target
+}
+
+func (p *protojsonPathFinder) objectEntry(v jsonValue, key string) (jsonPathSegment, jsonValue) {
+	switch {
+	case v.md != nil:
+		fd := p.ext.findField(v.md, key, false)
+		if fd == nil {
+			return jsonPathSegment{name: key, index: -1}, jsonValue{}
+		}
+		return jsonPathSegment{name: p.ext.fieldName(fd), fd: fd, index: -1}, fieldValue(fd, false)
+	case v.fd != nil && v.fd.IsMap():
+		return jsonPathSegment{name: key, isKey: true, index: -1}, fieldValue(v.fd, true)
+	case v.isStruct:
+		return jsonPathSegment{name: key, isKey: true, index: -1}, v
+	}
+	return jsonPathSegment{name: key, index: -1}, jsonValue{}
+}
+
+func (p *protojsonPathFinder) arrayElement(v jsonValue) jsonValue {
+	switch {
+	case v.fd != nil && v.fd.IsList():
+		return fieldValue(v.fd, true)
+	case v.isStruct:
+		return v
+	}
+	return jsonValue{}
+}
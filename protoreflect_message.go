@@ -0,0 +1,712 @@
+package jsoniterpb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// Messages without generated struct, e.g. *dynamicpb.Message, can not be handled by the struct codec of jsoniter,
+// so they are encoded and decoded via protoreflect instead, with the same options honored.
+
+var messageStateType = reflect.TypeOf(protoimpl.MessageState{})
+
+// isGeneratedMessageType reports whether typ is the struct type generated by protoc-gen-go (legacy ones are included),
+// whose fields are described with "protobuf" tags
+func isGeneratedMessageType(typ reflect2.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	structType := typ.(reflect2.StructType)
+	generated := false
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		// generated messages never embed anything, the methods are promoted from the embedded message otherwise
+		if field.Anonymous() {
+			return false
+		}
+		if field.Type().Type1() == messageStateType {
+			generated = true
+		}
+		if _, ok := field.Tag().Lookup("protobuf"); ok {
+			generated = true
+		}
+		if _, ok := field.Tag().Lookup("protobuf_oneof"); ok {
+			generated = true
+		}
+	}
+	return generated
+}
+
+// isProtoReflectMessageType reports whether typ is a message type which has no generated struct
+func isProtoReflectMessageType(typ reflect2.Type) bool {
+	if typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Interface {
+		return false
+	}
+	if !reflect2.PtrTo(typ).Implements(protoMessageType) {
+		return false
+	}
+	if _, ok := ProtoCodecs[typ]; ok {
+		return false
+	}
+	if typ.Kind() == reflect.Struct {
+		if isGeneratedMessageType(typ) {
+			return false
+		}
+		// the methods are promoted from the embedded message
+		structType := typ.(reflect2.StructType)
+		for i := 0; i < structType.NumField(); i++ {
+			if structType.Field(i).Anonymous() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// well known types are handled by the generated types, so that ProtoCodecs works for them
+var wellKnownTypesByFullName = map[protoreflect.FullName]reflect2.Type{}
+
+func init() {
+	for typ := range WellKnownTypes {
+		wellKnownTypesByFullName[typ.New().(proto.Message).ProtoReflect().Descriptor().FullName()] = typ
+	}
+}
+
+func isWellKnownMessage(m proto.Message) bool {
+	if IsWellKnownType(reflect2.TypeOf(m)) {
+		return true
+	}
+	_, ok := wellKnownTypesByFullName[m.ProtoReflect().Descriptor().FullName()]
+	return ok
+}
+
+func (e *ProtoExtension) createProtoReflectMessageEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	if !isProtoReflectMessageType(typ) {
+		return nil
+	}
+	return &protoReflectMessageEncoder{
+		ext:       e,
+		valueType: typ,
+	}
+}
+
+func (e *ProtoExtension) createProtoReflectMessageDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	// the extension fields can not be bound to the struct fields
+	if !isProtoReflectMessageType(typ) && !isExtendableMessageType(typ) {
+		return nil
+	}
+	return &protoReflectMessageDecoder{
+		ext:       e,
+		valueType: typ,
+	}
+}
+
+type protoReflectMessageEncoder struct {
+	ext       *ProtoExtension
+	valueType reflect2.Type
+
+	once        sync.Once
+	sortMapKeys bool
+}
+
+func (enc *protoReflectMessageEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	enc.once.Do(func() {
+		if fcfg, ok := stream.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok {
+			enc.sortMapKeys = fcfg.GetConfig().SortMapKeys
+		}
+	})
+
+	if hasStreamError(stream) {
+		return
+	}
+
+	m := enc.valueType.PackEFace(ptr).(proto.Message).ProtoReflect()
+	md := m.Descriptor()
+	if md == nil {
+		stream.WriteEmptyObject()
+		return
+	}
+
+	if !enc.ext.AllowPartial {
+		if err := checkRequiredFields(m); err != nil {
+			reportStreamError(stream, err)
+			return
+		}
+	}
+
+	if typ, ok := wellKnownTypesByFullName[md.FullName()]; ok {
+		wm := typ.New().(proto.Message)
+		if err := convertMessage(enc.ext.resolverOf(stream.Attachment), m.Interface(), wm); err != nil {
+			reportStreamError(stream, fmt.Errorf("%s: %v", md.FullName(), err))
+			return
+		}
+		stream.WriteVal(wm)
+		return
+	}
+
+	type fieldValue struct {
+		fd protoreflect.FieldDescriptor
+		v  protoreflect.Value
+	}
+	var fields []fieldValue
+	// the omit_empty option may emit the unpopulated fields without EmitUnpopulated, EmitDefaultValues and EmitPolicy
+	fds := md.Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if m.Has(fd) {
+			continue // populated fields are ranged below
+		}
+		if enc.ext.isIgnoredField(fd) {
+			continue
+		}
+		switch enc.ext.emitPolicy(fd, md.FullName()) {
+		case EmitPolicyOmitEmpty:
+			continue
+		case EmitPolicyNull:
+			fields = append(fields, fieldValue{fd, protoreflect.Value{}})
+			continue
+		case EmitPolicyAlways:
+			v := m.Get(fd)
+			if fd.HasPresence() {
+				v = protoreflect.Value{}
+			}
+			fields = append(fields, fieldValue{fd, v})
+			continue
+		}
+		if fd.ContainingOneof() != nil {
+			continue // fields within a oneof are ignored
+		}
+		if !enc.ext.EmitUnpopulated && fd.HasPresence() {
+			continue // EmitDefaultValues does not emit null
+		}
+		if !enc.ext.EmitUnpopulated && !enc.ext.EmitDefaultValues {
+			continue
+		}
+		v := m.Get(fd)
+		// same as protojson, emit null for proto2 scalars and singular messages
+		isProto2Scalar := fd.Syntax() == protoreflect.Proto2 && fd.Default().IsValid()
+		isSingularMessage := fd.Cardinality() != protoreflect.Repeated && fd.Message() != nil
+		if isProto2Scalar || isSingularMessage {
+			v = protoreflect.Value{}
+		}
+		fields = append(fields, fieldValue{fd, v})
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !enc.ext.isIgnoredField(fd) {
+			fields = append(fields, fieldValue{fd, v})
+		}
+		return true
+	})
+	// same order as protojson, fields by declaration index and then extension fields by full name
+	sort.Slice(fields, func(i, j int) bool {
+		x, y := fields[i].fd, fields[j].fd
+		if x.IsExtension() != y.IsExtension() {
+			return !x.IsExtension()
+		}
+		if x.IsExtension() {
+			return x.FullName() < y.FullName()
+		}
+		return x.Index() < y.Index()
+	})
+
+	stream.WriteObjectStart()
+	for i, f := range fields {
+		if i > 0 {
+			stream.WriteMore()
+		}
+		name := enc.ext.fieldName(f.fd)
+		stream.WriteObjectField(name)
+		enc.encodeValue(f.fd, f.v, enc.ext.int64AsNumber(f.fd), stream)
+		if e := streamError(stream); e != nil {
+			e.prependField(name, f.fd)
+			setStreamError(stream, e)
+			return
+		}
+	}
+	stream.WriteObjectEnd()
+}
+
+func (enc *protoReflectMessageEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return false
+}
+
+// encodeValue writes the value of fd, the 64-bit integers are not quoted if asNumber is set, see int64AsNumber
+func (enc *protoReflectMessageEncoder) encodeValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, asNumber bool, stream *jsoniter.Stream) {
+	switch {
+	case !v.IsValid():
+		stream.WriteNil()
+	case fd.IsList():
+		list := v.List()
+		stream.WriteArrayStart()
+		for i := 0; i < list.Len(); i++ {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			enc.encodeSingular(fd, list.Get(i), asNumber, stream)
+			if e := streamError(stream); e != nil {
+				e.prependIndex(i)
+				setStreamError(stream, e)
+				return
+			}
+		}
+		stream.WriteArrayEnd()
+	case fd.IsMap():
+		mp := v.Map()
+		keys := make([]protoreflect.MapKey, 0, mp.Len())
+		mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		if enc.sortMapKeys {
+			sortMapKeys(keys, fd.MapKey().Kind(), enc.ext.SortMapKeysAsString)
+		}
+		stream.WriteObjectStart()
+		for i, k := range keys {
+			if i > 0 {
+				stream.WriteMore()
+			}
+			stream.WriteObjectField(k.String())
+			enc.encodeSingular(fd.MapValue(), mp.Get(k), asNumber, stream)
+			if e := streamError(stream); e != nil {
+				e.prependKey(k.String())
+				setStreamError(stream, e)
+				return
+			}
+		}
+		stream.WriteObjectEnd()
+	default:
+		enc.encodeSingular(fd, v, asNumber, stream)
+	}
+}
+
+func (enc *protoReflectMessageEncoder) encodeSingular(fd protoreflect.FieldDescriptor, v protoreflect.Value, asNumber bool, stream *jsoniter.Stream) {
+	switch fd.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if asNumber {
+			stream.WriteInt64(v.Int())
+			return
+		}
+		stream.WriteVal(v.Interface())
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if asNumber {
+			stream.WriteUint64(v.Uint())
+			return
+		}
+		stream.WriteVal(v.Interface())
+	case protoreflect.EnumKind:
+		ed := fd.Enum()
+		if ed.FullName() == NullValue_enum_fullname {
+			stream.WriteNil()
+			return
+		}
+		n := v.Enum()
+		if !enc.ext.UseEnumNumbers {
+			if ev := ed.Values().ByNumber(n); ev != nil {
+				stream.WriteVal(string(ev.Name()))
+				return
+			}
+		}
+		stream.WriteVal(n)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		stream.WriteVal(v.Message().Interface())
+	default:
+		// scalars are encoded with the encoders of go types, so the options are the same
+		stream.WriteVal(v.Interface())
+	}
+}
+
+// same as protobuf-go GenericKeyOrder if not asString
+func sortMapKeys(keys []protoreflect.MapKey, kind protoreflect.Kind, asString bool) {
+	sort.Slice(keys, func(i, j int) bool {
+		x, y := keys[i], keys[j]
+		if asString {
+			return x.String() < y.String()
+		}
+		switch kind {
+		case protoreflect.BoolKind:
+			return !x.Bool() && y.Bool()
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return x.Int() < y.Int()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+			protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return x.Uint() < y.Uint()
+		}
+		return x.String() < y.String()
+	})
+}
+
+type protoReflectMessageDecoder struct {
+	ext       *ProtoExtension
+	valueType reflect2.Type
+
+	once                  sync.Once
+	disallowUnknownFields bool
+	caseSensitive         bool
+}
+
+func (dec *protoReflectMessageDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	dec.once.Do(func() {
+		if fcfg, ok := iter.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok {
+			dec.disallowUnknownFields = fcfg.GetConfig().DisallowUnknownFields
+			dec.caseSensitive = dec.ext.caseSensitive(fcfg.GetConfig())
+		}
+	})
+
+	start := iterOffset(iter)
+	m := dec.valueType.PackEFace(ptr).(proto.Message).ProtoReflect()
+	md := m.Descriptor()
+	if md == nil {
+		reportIterError(iter, start, fmt.Errorf("message descriptor of %v is missing", dec.valueType))
+		return
+	}
+
+	if typ, ok := wellKnownTypesByFullName[md.FullName()]; ok {
+		wm := typ.New().(proto.Message)
+		iter.ReadVal(wm)
+		if iter.Error != nil && iter.Error != io.EOF {
+			return
+		}
+		if err := convertMessage(dec.ext.resolverOf(iter.Attachment), wm, m.Interface()); err != nil {
+			reportIterError(iter, start, fmt.Errorf("%s: %v", md.FullName(), err))
+		}
+		return
+	}
+
+	if iter.ReadNil() {
+		return
+	}
+	if err := dec.ext.fieldsByNameOf(md).err; err != nil {
+		reportIterError(iter, start, err)
+		return
+	}
+
+	checker := &duplicateFieldChecker{
+		ext:                     dec.ext,
+		md:                      md,
+		disallowDuplicateFields: dec.ext.disallowDuplicateFields(),
+	}
+	iter.ReadMapCB(func(iter *jsoniter.Iterator, field string) bool {
+		offset := iterOffset(iter)
+		var fd protoreflect.FieldDescriptor
+		if isExtensionFieldName(field) {
+			xt, err := dec.ext.findExtensionField(iter.Attachment, md, field, dec.disallowUnknownFields)
+			if err != nil {
+				reportIterError(iter, offset, err)
+				return false
+			}
+			if xt != nil {
+				fd = xt.TypeDescriptor()
+			}
+		} else {
+			fd = dec.ext.findFieldByName(md, field, dec.caseSensitive)
+			if fd == nil && dec.disallowUnknownFields {
+				reportIterError(iter, offset, errors.New("found unknown field: "+field))
+				return false
+			}
+		}
+		if fd == nil {
+			iter.Skip()
+			return true
+		}
+		if err := checker.check(fd, iter.WhatIsNext() == jsoniter.NilValue); err != nil {
+			reportIterError(iter, offset, err)
+			return false
+		}
+
+		name := dec.ext.fieldName(fd)
+		c := fuzzyDecodeCollectorOf(iter)
+		if c != nil {
+			c.pushField(name, fd)
+		}
+		dec.decodeField(m, fd, iter)
+		if c != nil {
+			c.pop()
+		}
+		if e := iterError(iter); e != nil {
+			e.prependField(name, fd)
+			setIterError(iter, e)
+			return false
+		}
+		return true
+	})
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+
+	if !dec.ext.AllowPartial {
+		if err := checkRequiredFields(m); err != nil {
+			reportIterError(iter, start, err)
+		}
+	}
+}
+
+func (dec *protoReflectMessageDecoder) decodeField(m protoreflect.Message, fd protoreflect.FieldDescriptor, iter *jsoniter.Iterator) {
+	// null is the same as unset, except for google.protobuf.Value and google.protobuf.NullValue
+	if iter.WhatIsNext() == jsoniter.NilValue && (fd.IsList() || fd.IsMap() || !isNullable(fd)) {
+		iter.Skip()
+		return
+	}
+
+	switch {
+	case fd.IsList():
+		list := m.NewField(fd).List()
+		// the index of the input, the elements of unknown enum names are left out of the list
+		index := 0
+		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			if iter.WhatIsNext() == jsoniter.NilValue && !dec.ext.fuzzyDecode(FuzzyDecodeNullToZero) && !isNullable(fd) {
+				reportIterError(iter, iterOffset(iter), errNullElement)
+				e := iterError(iter)
+				e.prependIndex(index)
+				setIterError(iter, e)
+				return false
+			}
+			if c := fuzzyDecodeCollectorOf(iter); c != nil {
+				c.pushIndex(index)
+				defer c.pop()
+				if iter.WhatIsNext() == jsoniter.NilValue && !isNullable(fd) {
+					c.report(FuzzyDecodeNullToZero)
+				}
+			}
+			v := dec.decodeSingular(fd, list.NewElement, iter)
+			if e := iterError(iter); e != nil {
+				e.prependIndex(index)
+				setIterError(iter, e)
+				return false
+			}
+			if v.IsValid() {
+				list.Append(v)
+			}
+			index++
+			return true
+		})
+		if iter.Error != nil && iter.Error != io.EOF {
+			return
+		}
+		m.Set(fd, protoreflect.ValueOfList(list))
+	case fd.IsMap():
+		mp := m.Mutable(fd).Map()
+		seen := map[interface{}]bool{}
+		iter.ReadMapCB(func(iter *jsoniter.Iterator, key string) bool {
+			offset := iterOffset(iter)
+			k, err := parseMapKey(fd.MapKey(), key)
+			if err != nil {
+				reportIterError(iter, offset, err)
+				return false
+			}
+			if dec.ext.disallowDuplicateFields() {
+				if seen[k.Interface()] {
+					reportIterError(iter, offset, fmt.Errorf("duplicate map key %q", key))
+					return false
+				}
+				seen[k.Interface()] = true
+			}
+			if iter.WhatIsNext() == jsoniter.NilValue && !dec.ext.fuzzyDecode(FuzzyDecodeNullToZero) && !isNullable(fd.MapValue()) {
+				reportIterError(iter, iterOffset(iter), errNullElement)
+				e := iterError(iter)
+				e.prependKey(key)
+				setIterError(iter, e)
+				return false
+			}
+			if c := fuzzyDecodeCollectorOf(iter); c != nil {
+				c.pushKey(key)
+				defer c.pop()
+				if iter.WhatIsNext() == jsoniter.NilValue && !isNullable(fd.MapValue()) {
+					c.report(FuzzyDecodeNullToZero)
+				}
+			}
+			v := dec.decodeSingular(fd.MapValue(), mp.NewValue, iter)
+			if e := iterError(iter); e != nil {
+				e.prependKey(key)
+				setIterError(iter, e)
+				return false
+			}
+			if v.IsValid() {
+				mp.Set(k, v)
+			}
+			return true
+		})
+	case fd.Message() != nil:
+		// merge into the existing one
+		dec.decodeSingular(fd, func() protoreflect.Value { return m.Mutable(fd) }, iter)
+	default:
+		v := dec.decodeSingular(fd, nil, iter)
+		if iter.Error != nil && iter.Error != io.EOF || !v.IsValid() {
+			return
+		}
+		m.Set(fd, v)
+	}
+}
+
+func isNullable(fd protoreflect.FieldDescriptor) bool {
+	if md := fd.Message(); md != nil {
+		return md.FullName() == Value_message_fullname
+	}
+	if ed := fd.Enum(); ed != nil {
+		return ed.FullName() == NullValue_enum_fullname
+	}
+	return false
+}
+
+// newMessage is only used for message kind
+func (dec *protoReflectMessageDecoder) decodeSingular(fd protoreflect.FieldDescriptor, newMessage func() protoreflect.Value, iter *jsoniter.Iterator) protoreflect.Value {
+	// scalars are decoded with the decoders of go types, so the options are the same
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var x bool
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfBool(x)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var x int32
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfInt32(x)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var x int64
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfInt64(x)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var x uint32
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfUint32(x)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var x uint64
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfUint64(x)
+	case protoreflect.FloatKind:
+		var x float32
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfFloat32(x)
+	case protoreflect.DoubleKind:
+		var x float64
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfFloat64(x)
+	case protoreflect.StringKind:
+		var x string
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfString(x)
+	case protoreflect.BytesKind:
+		var x []byte
+		iter.ReadVal(&x)
+		return protoreflect.ValueOfBytes(x)
+	case protoreflect.EnumKind:
+		n, ok := decodeEnumNumber(dec.ext, fd.Enum(), iter, !dec.disallowUnknownFields)
+		if !ok {
+			return protoreflect.Value{}
+		}
+		return protoreflect.ValueOfEnum(n)
+	default:
+		v := newMessage()
+		iter.ReadVal(v.Message().Interface())
+		return v
+	}
+}
+
+// same as protoEnumDecoder, it returns false if the unknown name is ignored
+func decodeEnumNumber(e *ProtoExtension, ed protoreflect.EnumDescriptor, iter *jsoniter.Iterator, discardUnknown bool) (protoreflect.EnumNumber, bool) {
+	valueType := iter.WhatIsNext()
+	start := iterOffset(iter)
+	switch valueType {
+	case jsoniter.NumberValue:
+		return protoreflect.EnumNumber(iter.ReadInt32()), true
+	case jsoniter.StringValue:
+		var name string
+		iter.ReadVal(&name)
+		if ev := ed.Values().ByName(protoreflect.Name(name)); ev != nil {
+			return ev.Number(), true
+		}
+		// is "num"?
+		num, err := strconv.ParseInt(name, 10, 32)
+		if err != nil || !e.fuzzyDecode(FuzzyDecodeEnumNumericString) {
+			if discardUnknown {
+				return 0, false
+			}
+			reportIterError(iter, start, fmt.Errorf(
+				"error decode from string for type %s",
+				ed.FullName(),
+			))
+			return 0, true
+		}
+		reportFuzzyDecode(iter, FuzzyDecodeEnumNumericString)
+		return protoreflect.EnumNumber(num), true
+	case jsoniter.NilValue:
+		iter.Skip()
+		return 0, true
+	default:
+		reportIterError(iter, start, fmt.Errorf(
+			"error decode for type %s",
+			ed.FullName(),
+		))
+		return 0, true
+	}
+}
+
+func parseMapKey(fd protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(key)
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(key)
+		if err != nil || (key != "true" && key != "false") {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q", key)
+		}
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q", key)
+		}
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q", key)
+		}
+		v = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q", key)
+		}
+		v = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid map key %q", key)
+		}
+		v = protoreflect.ValueOfUint64(n)
+	default:
+		return protoreflect.MapKey{}, fmt.Errorf("invalid map key kind %v", fd.Kind())
+	}
+	return v.MapKey(), nil
+}
+
+// convertMessage converts between the generated and the dynamic message which have the same descriptor
+func convertMessage(resolver protoregistry.ExtensionTypeResolver, src, dst proto.Message) error {
+	b, err := proto.MarshalOptions{AllowPartial: true}.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return proto.UnmarshalOptions{
+		Merge:        true,
+		AllowPartial: true,
+		Resolver:     resolver,
+	}.Unmarshal(b, dst)
+}
@@ -0,0 +1,93 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Each message checks its own required fields,
+// so nested messages, repeated fields, map values and messages inside google.protobuf.Any are covered naturally.
+
+func (e *ProtoExtension) decorateEncoderForRequired(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if e.AllowPartial {
+		return nil
+	}
+	md := protoMessageDescriptor(typ)
+	if md == nil || md.RequiredNumbers().Len() <= 0 {
+		return nil
+	}
+	return &protoRequiredEncoder{
+		valueType:    typ,
+		valueEncoder: encoder,
+	}
+}
+
+func (e *ProtoExtension) decorateDecoderForRequired(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if e.AllowPartial {
+		return nil
+	}
+	md := protoMessageDescriptor(typ)
+	if md == nil || md.RequiredNumbers().Len() <= 0 {
+		return nil
+	}
+	// checked by protoReflectMessageDecoder
+	if md.ExtensionRanges().Len() > 0 {
+		return nil
+	}
+	return &protoRequiredDecoder{
+		valueType:    typ,
+		valueDecoder: decoder,
+	}
+}
+
+func checkRequiredFields(m protoreflect.Message) error {
+	md := m.Descriptor()
+	fds := md.Fields()
+	nums := md.RequiredNumbers()
+	for i := 0; i < nums.Len(); i++ {
+		fd := fds.ByNumber(nums.Get(i))
+		if !m.Has(fd) {
+			return fmt.Errorf("required field %v not set", fd.FullName())
+		}
+	}
+	return nil
+}
+
+type protoRequiredEncoder struct {
+	valueType    reflect2.Type
+	valueEncoder jsoniter.ValEncoder
+}
+
+func (enc *protoRequiredEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if err := checkRequiredFields(enc.valueType.PackEFace(ptr).(proto.Message).ProtoReflect()); err != nil {
+		reportStreamError(stream, err)
+		return
+	}
+	enc.valueEncoder.Encode(ptr, stream)
+}
+
+func (enc *protoRequiredEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	return enc.valueEncoder.IsEmpty(ptr)
+}
+
+type protoRequiredDecoder struct {
+	valueType    reflect2.Type
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *protoRequiredDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	start := iterOffset(iter)
+	dec.valueDecoder.Decode(ptr, iter)
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	if err := checkRequiredFields(dec.valueType.PackEFace(ptr).(proto.Message).ProtoReflect()); err != nil {
+		reportIterError(iter, start, err)
+	}
+}
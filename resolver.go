@@ -0,0 +1,214 @@
+package jsoniterpb
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// NewResolverFromDescriptorSet returns a resolver of the types in the serialized google.protobuf.FileDescriptorSet,
+// e.g. the output of `protoc --descriptor_set_out`, which can be used as ProtoExtension.Resolver.
+// The messages are dynamicpb.Message, and the imports which are not in the set are looked up in protoregistry.GlobalFiles.
+func NewResolverFromDescriptorSet(b []byte) (*protoregistry.Types, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal the descriptor set: %w", err)
+	}
+	files, err := newFilesFromDescriptorSet(fds)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolverFromFiles(files)
+}
+
+// NewResolverFromDescriptorSetFiles is the same as NewResolverFromDescriptorSet, but reads the descriptor sets from the named files,
+// a file which is in more than one of them must be the same.
+func NewResolverFromDescriptorSetFiles(names ...string) (*protoregistry.Types, error) {
+	fds := &descriptorpb.FileDescriptorSet{}
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(b, fds); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal the descriptor set %q: %w", name, err)
+		}
+	}
+	files, err := newFilesFromDescriptorSet(fds)
+	if err != nil {
+		return nil, err
+	}
+	return NewResolverFromFiles(files)
+}
+
+// NewResolverFromFiles returns a resolver of the messages and the extensions declared in files, which are dynamicpb types.
+func NewResolverFromFiles(files *protoregistry.Files) (*protoregistry.Types, error) {
+	types := &protoregistry.Types{}
+	var err error
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = registerTypes(types, fd.Messages(), fd.Extensions())
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func registerTypes(types *protoregistry.Types, mds protoreflect.MessageDescriptors, xds protoreflect.ExtensionDescriptors) error {
+	for i := 0; i < xds.Len(); i++ {
+		if err := types.RegisterExtension(dynamicpb.NewExtensionType(xds.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		if err := types.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+			return err
+		}
+		if err := registerTypes(types, md.Messages(), md.Extensions()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newFilesFromDescriptorSet is the same as protodesc.NewFiles,
+// except that the imports which are not in fds are looked up in protoregistry.GlobalFiles
+func newFilesFromDescriptorSet(fds *descriptorpb.FileDescriptorSet) (*protoregistry.Files, error) {
+	protos := map[string]*descriptorpb.FileDescriptorProto{}
+	for _, fdp := range fds.GetFile() {
+		if prev, ok := protos[fdp.GetName()]; ok {
+			if !proto.Equal(prev, fdp) {
+				return nil, fmt.Errorf("file %q appears more than once with different contents", fdp.GetName())
+			}
+			continue
+		}
+		protos[fdp.GetName()] = fdp
+	}
+
+	files := &protoregistry.Files{}
+	r := &descriptorSetResolver{files: files}
+	var register func(path string, importer string) error
+	register = func(path string, importer string) error {
+		if _, err := files.FindFileByPath(path); err == nil {
+			return nil
+		}
+		fdp, ok := protos[path]
+		if !ok {
+			if _, err := protoregistry.GlobalFiles.FindFileByPath(path); err == nil {
+				return nil
+			}
+			return fmt.Errorf("could not resolve import %q of %q: %w", path, importer, protoregistry.NotFound)
+		}
+		for _, dep := range fdp.GetDependency() {
+			if err := register(dep, path); err != nil {
+				return err
+			}
+		}
+		fd, err := protodesc.NewFile(fdp, r)
+		if err != nil {
+			return err
+		}
+		return files.RegisterFile(fd)
+	}
+	for _, fdp := range fds.GetFile() {
+		if err := register(fdp.GetName(), ""); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// descriptorSetResolver finds the descriptors in files first, and then in protoregistry.GlobalFiles
+type descriptorSetResolver struct {
+	files *protoregistry.Files
+}
+
+func (r *descriptorSetResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r *descriptorSetResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// SwappableResolver is a resolver which can be replaced at runtime, e.g. when the descriptor sets are updated,
+// the configs using it as ProtoExtension.Resolver resolve the types with the new one without freezing again.
+// It is safe for concurrent use.
+//
+//	r := jsoniterpb.NewSwappableResolver(nil)
+//	cfg.RegisterExtension(&jsoniterpb.ProtoExtension{Resolver: r})
+//	types, err := jsoniterpb.NewResolverFromDescriptorSet(b)
+//	r.Store(types)
+type SwappableResolver struct {
+	v atomic.Value // resolverHolder
+}
+
+type resolverHolder struct {
+	resolver interface {
+		protoregistry.MessageTypeResolver
+		protoregistry.ExtensionTypeResolver
+	}
+}
+
+// NewSwappableResolver returns a SwappableResolver which uses r, protoregistry.GlobalTypes is used if r is nil.
+func NewSwappableResolver(r interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}) *SwappableResolver {
+	sr := &SwappableResolver{}
+	sr.Store(r)
+	return sr
+}
+
+// Store replaces the resolver with r, protoregistry.GlobalTypes is used if r is nil.
+func (sr *SwappableResolver) Store(r interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}) {
+	sr.v.Store(resolverHolder{resolver: r})
+}
+
+// Load returns the resolver in use.
+func (sr *SwappableResolver) Load() interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+} {
+	if h, ok := sr.v.Load().(resolverHolder); ok && h.resolver != nil {
+		return h.resolver
+	}
+	return protoregistry.GlobalTypes
+}
+
+func (sr *SwappableResolver) FindMessageByName(message protoreflect.FullName) (protoreflect.MessageType, error) {
+	return sr.Load().FindMessageByName(message)
+}
+
+func (sr *SwappableResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	return sr.Load().FindMessageByURL(url)
+}
+
+func (sr *SwappableResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return sr.Load().FindExtensionByName(field)
+}
+
+func (sr *SwappableResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return sr.Load().FindExtensionByNumber(message, field)
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math"
 	"reflect"
 	"strings"
@@ -99,6 +98,7 @@ func (e *ProtoExtension) decorateDecoderForScalar(typ reflect2.Type, dec jsonite
 		return &funcDecoder{
 			fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 				if iter.WhatIsNext() == jsoniter.StringValue {
+					start := iterOffset(iter)
 					s := iter.ReadString()
 					// copy from protobuf-go
 					enc := base64.StdEncoding
@@ -111,7 +111,7 @@ func (e *ProtoExtension) decorateDecoderForScalar(typ reflect2.Type, dec jsonite
 
 					dst, err := enc.DecodeString(s)
 					if err != nil {
-						iter.ReportError("decode base64", err.Error())
+						reportIterError(iter, start, fmt.Errorf("decode base64: %w", err))
 					} else {
 						typ.UnsafeSet(ptr, unsafe.Pointer(&dst))
 					}
@@ -122,24 +122,31 @@ func (e *ProtoExtension) decorateDecoderForScalar(typ reflect2.Type, dec jsonite
 		}
 	}
 
-	if !e.DisableFuzzyDecode {
+	fuzzy := false
+	if !e.DisableFuzzyDecode || e.Strict {
 		if ddec, ok := fuzzyDecorateScalarDecoders[typ.Kind()]; ok {
 			dec = ddec(e, dec)
+			fuzzy = true
 		}
 	}
 
 	var bitSize int
 	switch typ.Kind() {
 	case reflect.Int64, reflect.Uint64:
+		// the fuzzy decoder reads the string itself, so that the rules of strings are applied
+		if fuzzy {
+			return dec
+		}
 		return &stringModeNumberDecoder{elemDecoder: dec}
 	case reflect.String:
 		return &funcDecoder{
 			fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+				start := iterOffset(iter)
 				dec.Decode(ptr, iter)
 				if iter.Error == nil {
 					if !e.PermitInvalidUTF8 {
 						if !utf8.ValidString(*((*string)(ptr))) {
-							iter.Error = errInvalidUTF8
+							reportIterError(iter, start, errInvalidUTF8)
 						}
 					}
 				}
@@ -158,6 +165,7 @@ func (e *ProtoExtension) decorateDecoderForScalar(typ reflect2.Type, dec jsonite
 	return &funcDecoder{
 		fun: func(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 			if iter.WhatIsNext() == jsoniter.StringValue {
+				start := iterOffset(iter)
 				b := iter.SkipAndReturnBytes()
 				if bytes.Equal(b, nanBytes) {
 					if bitSize == 32 {
@@ -182,9 +190,7 @@ func (e *ProtoExtension) decorateDecoderForScalar(typ reflect2.Type, dec jsonite
 					subIter.Attachment = iter.Attachment
 					defer iter.API().ReturnIterator(subIter)
 					dec.Decode(ptr, subIter)
-					if subIter.Error != nil && subIter.Error != io.EOF && iter.Error == nil {
-						iter.Error = subIter.Error
-					}
+					transferIterError(iter, subIter, offsetFrom(start))
 				}
 				return
 			}
@@ -216,7 +222,7 @@ func (encoder *protoStringEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.S
 		buf, err = QuoteValidUTF8String(str)
 	}
 	if err != nil {
-		stream.Error = fmt.Errorf("ProtoStringEncoder: %w", err)
+		reportStreamError(stream, fmt.Errorf("ProtoStringEncoder: %w", err))
 		return
 	}
 	stream.Write(buf)
@@ -0,0 +1,81 @@
+package jsoniterpb
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// With Strict, the input is accepted only if protojson accepts it:
+// - the fuzzy decode rules are disabled, except the ones protojson also accepts, e.g. "1" and 1e2 for int32
+// - the fields, the oneofs and the map keys which appear more than once are rejected
+// - the field names are case sensitive even if CaseSensitive of jsoniter.Config is not set,
+//   the generated messages are decoded via protoreflect in that case, since the struct decoder of jsoniter matches the names case insensitively
+// - null is rejected for a message, except for google.protobuf.Value, e.g. the top level one
+
+// strictFuzzyDecodeOptions is the fuzzy decode rules which protojson also accepts
+const strictFuzzyDecodeOptions = FuzzyDecodeNumericString | FuzzyDecodeExponentInteger
+
+// caseSensitive reports whether the field names are matched case sensitively, which is the same as the struct decoder of jsoniter except with Strict
+func (e *ProtoExtension) caseSensitive(cfg jsoniter.Config) bool {
+	return cfg.CaseSensitive || e.Strict
+}
+
+var errNullMessage = errors.New("unexpected token null")
+
+func (e *ProtoExtension) decorateDecoderForStrict(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if !e.Strict {
+		return nil
+	}
+	if typ.Kind() != reflect.Struct || !reflect2.PtrTo(typ).Implements(protoMessageType) || typ == wktValuePtrType.(reflect2.PtrType).Elem() {
+		return nil
+	}
+	return &protoStrictDecoder{
+		ext:          e,
+		valueType:    typ,
+		valueDecoder: decoder,
+	}
+}
+
+type protoStrictDecoder struct {
+	ext          *ProtoExtension
+	valueType    reflect2.Type
+	valueDecoder jsoniter.ValDecoder
+
+	once sync.Once
+	// reflectDecoder decodes the generated message case sensitively if CaseSensitive of jsoniter.Config is not set
+	reflectDecoder jsoniter.ValDecoder
+}
+
+func (dec *protoStrictDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	dec.once.Do(func() {
+		if fcfg, ok := iter.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok && fcfg.GetConfig().CaseSensitive {
+			return
+		}
+		// the others are decoded via protoreflect already, or have no fields by names, e.g. the well-known types
+		if protoMessageDescriptor(dec.valueType) != nil && !isExtendableMessageType(dec.valueType) {
+			dec.reflectDecoder = &protoReflectMessageDecoder{
+				ext:       dec.ext,
+				valueType: dec.valueType,
+			}
+		}
+	})
+	if iter.WhatIsNext() == jsoniter.NilValue {
+		reportIterError(iter, iterOffset(iter), errNullMessage)
+		return
+	}
+	if dec.reflectDecoder == nil {
+		dec.valueDecoder.Decode(ptr, iter)
+		return
+	}
+	if st, ok := enterIterCall(iter); ok {
+		defer leaveIterCall(iter, st)
+	}
+	dec.reflectDecoder.Decode(ptr, iter)
+}
@@ -0,0 +1,64 @@
+package jsoniterpb
+
+import (
+	"reflect"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protojson ignores an unknown enum name like an unknown field if DiscardUnknown is set,
+// so the field is left unset and the element of a repeated field or a map field is left out.
+// protoEnumDecoder leaves the value untouched and marks it in the state of the call, which is enough for the enum fields without presence,
+// the optional fields, the members of oneof and the elements are undone by their decoders, see takeUnknownEnum.
+
+// discardUnknown reports whether the unknown fields are ignored by the config of iter
+func discardUnknown(iter *jsoniter.Iterator) bool {
+	if fcfg, ok := iter.API().(interface {
+		GetConfig() jsoniter.Config
+	}); ok {
+		return !fcfg.GetConfig().DisallowUnknownFields
+	}
+	return false
+}
+
+// markUnknownEnum marks that the unknown enum name just read by iter is ignored
+func markUnknownEnum(iter *jsoniter.Iterator) {
+	if st, ok := iter.Attachment.(*callState); ok {
+		st.unknownEnum = true
+	}
+}
+
+// takeUnknownEnum reports whether an unknown enum name is ignored since the last call,
+// it is called before decoding a value to clear the mark and after that to check it
+func takeUnknownEnum(iter *jsoniter.Iterator) bool {
+	st, ok := iter.Attachment.(*callState)
+	if !ok || !st.unknownEnum {
+		return false
+	}
+	st.unknownEnum = false
+	return true
+}
+
+func (e *ProtoExtension) wrapFieldDecoderForUnknownEnum(fd protoreflect.FieldDescriptor, fieldType reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	if fd == nil || fd.IsList() || fd.IsMap() || fd.Enum() == nil || fieldType.Kind() != reflect.Ptr {
+		return nil
+	}
+	return &protoOptionalEnumDecoder{decoder}
+}
+
+// protoOptionalEnumDecoder leaves the optional enum field unset if the name is unknown
+type protoOptionalEnumDecoder struct {
+	valueDecoder jsoniter.ValDecoder
+}
+
+func (dec *protoOptionalEnumDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	old := *((*unsafe.Pointer)(ptr))
+	takeUnknownEnum(iter)
+	dec.valueDecoder.Decode(ptr, iter)
+	if takeUnknownEnum(iter) {
+		*((*unsafe.Pointer)(ptr)) = old
+	}
+}
@@ -1,14 +1,17 @@
 package jsoniterpb
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"io"
+	"sync"
 	"unsafe"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/modern-go/reflect2"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -21,6 +24,9 @@ type wktAnyEncoder struct {
 }
 
 func (c *wktAnyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	if hasStreamError(stream) {
+		return
+	}
 	m := ((*anypb.Any)(ptr))
 
 	if m.GetTypeUrl() == "" {
@@ -28,39 +34,50 @@ func (c *wktAnyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 			stream.WriteEmptyObject()
 			return
 		}
-		stream.Error = fmt.Errorf(`%s: "type_url" is not set, but "value" is set.`, Any_message_fullname)
+		reportStreamError(stream, fmt.Errorf(`%s: "type_url" is not set, but "value" is set.`, Any_message_fullname))
 		return
 	}
 
-	resolver := c.ext.GetResolver()
+	resolver := c.ext.anyResolver(stream.Attachment, m.GetTypeUrl())
+	typeUrl := c.ext.encodedAnyTypeURL(m.GetTypeUrl())
 
 	// Resolve the type in order to unmarshal value field.
 	emt, err := resolver.FindMessageByURL(m.GetTypeUrl())
+	if err != nil && c.ext.OpaqueAny && errors.Is(err, protoregistry.NotFound) {
+		encodeOpaqueAny(typeUrl, m.GetValue(), stream)
+		return
+	}
 	if err != nil {
-		stream.Error = fmt.Errorf("%s: unable to resolve %q: %v", Any_message_fullname, m.GetTypeUrl(), err)
+		reportStreamError(stream, fmt.Errorf("%s: unable to resolve %q: %v", Any_message_fullname, m.GetTypeUrl(), err))
 		return
 	}
 
 	em := emt.New().Interface()
 	err = proto.UnmarshalOptions{
-		AllowPartial: true, // never check required fields inside an Any
+		AllowPartial: true, // required fields are checked when encoding the embedded message below
 		Resolver:     resolver,
 	}.Unmarshal(m.GetValue(), em)
 	if err != nil {
-		stream.Error = fmt.Errorf("%s: unable to unmarshal %q: %v", Any_message_fullname, m.GetTypeUrl(), err)
+		reportStreamError(stream, fmt.Errorf("%s: unable to unmarshal %q: %v", Any_message_fullname, m.GetTypeUrl(), err))
 		return
 	}
 
 	// If type of value has custom JSON encoding, marshal out a field "value"
 	// with corresponding custom JSON encoding of the embedded message as a
 	// field.
-	if IsWellKnownType(reflect2.TypeOf(em)) {
+	if isWellKnownMessage(em) {
 		stream.WriteObjectStart()
 		stream.WriteObjectField("@type")
-		stream.WriteVal(m.GetTypeUrl())
+		stream.WriteVal(typeUrl)
 		stream.WriteMore()
 		stream.WriteObjectField("value")
 		stream.WriteVal(em)
+		if e := streamError(stream); e != nil {
+			e.Err = fmt.Errorf("%s: unable to marshal %q: %w", Any_message_fullname, m.GetTypeUrl(), e.Err)
+			e.prependField("value", nil)
+			setStreamError(stream, e)
+			return
+		}
 		stream.WriteObjectEnd()
 		return
 	}
@@ -70,8 +87,10 @@ func (c *wktAnyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 	subStream.Attachment = stream.Attachment
 	defer stream.API().ReturnStream(subStream)
 	subStream.WriteVal(em)
-	if subStream.Error != nil && subStream.Error != io.EOF {
-		stream.Error = fmt.Errorf("%s: unable to marshal %q: %v", Any_message_fullname, m.GetTypeUrl(), subStream.Error)
+	if e := streamError(subStream); e != nil {
+		// the path inside the embedded message is kept, since its fields are inlined
+		e.Err = fmt.Errorf("%s: unable to marshal %q: %w", Any_message_fullname, m.GetTypeUrl(), e.Err)
+		setStreamError(stream, e)
 		return
 	}
 
@@ -80,7 +99,7 @@ func (c *wktAnyEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
 
 	stream.WriteObjectStart()
 	stream.WriteObjectField("@type")
-	stream.WriteVal(m.GetTypeUrl())
+	stream.WriteVal(typeUrl)
 	subIter.ReadObjectCB(func(iter *jsoniter.Iterator, field string) bool {
 		stream.WriteMore()
 		stream.WriteObjectField(field)
@@ -96,21 +115,36 @@ func (c *wktAnyEncoder) IsEmpty(ptr unsafe.Pointer) bool {
 
 type wktAnyDecoder struct {
 	ext *ProtoExtension
+
+	once           sync.Once
+	discardUnknown bool
 }
 
 func (c *wktAnyDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	c.once.Do(func() {
+		if fcfg, ok := iter.API().(interface {
+			GetConfig() jsoniter.Config
+		}); ok {
+			c.discardUnknown = !fcfg.GetConfig().DisallowUnknownFields
+		}
+	})
 	m := ((*anypb.Any)(ptr))
 
+	start := iterOffset(iter)
 	var typeUrl string
-	var valueBytes []byte
+	var valueBytes, opaqueBytes []byte
+	valueOffset, opaqueOffset := -1, -1
 	fields := map[string]bool{}
 
 	subStream := iter.API().BorrowStream(nil)
 	defer iter.API().ReturnStream(subStream)
+	var mapping offsetMapping
+	more := false
 	subStream.WriteObjectStart()
 	iter.ReadMapCB(func(iter *jsoniter.Iterator, field string) bool {
+		offset := iterOffset(iter)
 		if fields[field] {
-			iter.ReportError("protobuf", fmt.Sprintf("%s: duplicate %q field", Any_message_fullname, field))
+			reportIterError(iter, offset, fmt.Errorf("%s: duplicate %q field", Any_message_fullname, field))
 			return false
 		}
 		fields[field] = true
@@ -121,12 +155,26 @@ func (c *wktAnyDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 		value := iter.SkipAndReturnBytes()
 		if field == "value" {
 			valueBytes = value
+			valueOffset = offset
 		}
+		if field == "@value" {
+			opaqueBytes = value
+			opaqueOffset = offset
+		}
+		if more {
+			subStream.WriteMore()
+		}
+		more = true
 		subStream.WriteObjectField(field)
+		mapping.add(len(subStream.Buffer()), offset, len(value))
 		subStream.Write(value)
 		return true
 	})
 	subStream.WriteObjectEnd()
+	if e := iterError(iter); e != nil {
+		setIterError(iter, e)
+		return
+	}
 
 	if len(fields) <= 0 {
 		// empty any object
@@ -135,38 +183,71 @@ func (c *wktAnyDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 		return
 	}
 
+	if typeUrl != "" && !c.ext.isAllowedAnyTypeURLPrefix(typeUrl) {
+		reportIterError(iter, start, errDisallowedAnyTypeURLPrefix(typeUrl))
+		return
+	}
+	if typeUrl != "" && !c.ext.isAllowedAnyType(typeUrl) {
+		reportIterError(iter, start, errDisallowedAnyType(typeUrl))
+		return
+	}
+
+	if c.ext.OpaqueAny && len(fields) == 2 && typeUrl != "" && fields["@value"] {
+		decodeOpaqueAny(c.ext, m, typeUrl, opaqueBytes, opaqueOffset, iter)
+		return
+	}
+
 	if typeUrl == "" {
 		if fields["@type"] {
-			iter.ReportError("protobuf", fmt.Sprintf(`%s: "@type" field contains empty value`, Any_message_fullname))
+			reportIterError(iter, start, fmt.Errorf(`%s: "@type" field contains empty value`, Any_message_fullname))
 			return
 		}
-		iter.ReportError("protobuf", fmt.Sprintf(`%s: missing "@type" field`, Any_message_fullname))
+		// all of the fields are unknown if the type is missing, which is the same as protojson
+		if c.discardUnknown {
+			return
+		}
+		reportIterError(iter, start, fmt.Errorf(`%s: missing "@type" field`, Any_message_fullname))
 		return
 	}
 
-	resolver := c.ext.GetResolver()
+	resolver := c.ext.anyResolver(iter.Attachment, typeUrl)
 	emt, err := resolver.FindMessageByURL(typeUrl)
 	if err != nil {
-		iter.ReportError("protobuf", fmt.Sprintf("%s: unable to resolve %q: %v", Any_message_fullname, typeUrl, err))
+		// and so are the ones of the type which can not be resolved, e.g. a new type of a newer version
+		if c.discardUnknown && errors.Is(err, protoregistry.NotFound) {
+			return
+		}
+		reportIterError(iter, start, fmt.Errorf("%s: unable to resolve %q: %v", Any_message_fullname, typeUrl, err))
 		return
 	}
 	em := emt.New().Interface()
 
 	var subIter *jsoniter.Iterator
-	if IsWellKnownType(reflect2.TypeOf(em)) {
+	var offsetOf func(int) int
+	isWellKnown := isWellKnownMessage(em)
+	if isWellKnown {
 		if !fields["value"] {
-			iter.ReportError("protobuf", fmt.Sprintf(`%s: missing "value" field`, Any_message_fullname))
+			reportIterError(iter, start, fmt.Errorf(`%s: missing "value" field`, Any_message_fullname))
 			return
 		}
 		subIter = iter.API().BorrowIterator(valueBytes)
+		offsetOf = offsetFrom(valueOffset)
 	} else {
 		subIter = iter.API().BorrowIterator(subStream.Buffer())
+		offsetOf = mapping.offsetOf()
 	}
 	subIter.Attachment = iter.Attachment
 	defer iter.API().ReturnIterator(subIter)
+	if c := fuzzyDecodeCollectorOf(iter); c != nil && isWellKnown {
+		c.pushField("value", nil)
+		defer c.pop()
+	}
 	subIter.ReadVal(em)
-	if subIter.Error != nil && subIter.Error != io.EOF {
-		iter.ReportError("protobuf", fmt.Sprintf("%s: unable to unmarshal %q: %v", Any_message_fullname, typeUrl, subIter.Error))
+	if e := transferIterError(iter, subIter, offsetOf); e != nil {
+		e.Err = fmt.Errorf("%s: unable to unmarshal %q: %w", Any_message_fullname, typeUrl, e.Err)
+		if isWellKnown {
+			e.prependField("value", nil)
+		}
 		return
 	}
 
@@ -175,7 +256,7 @@ func (c *wktAnyDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 		Deterministic: true,
 	}.Marshal(em)
 	if err != nil {
-		iter.ReportError("protobuf", fmt.Sprintf("error in marshaling Any.value field: %v", err))
+		reportIterError(iter, start, fmt.Errorf("error in marshaling Any.value field: %v", err))
 		return
 	}
 
@@ -183,6 +264,56 @@ func (c *wktAnyDecoder) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
 	m.Value = b
 }
 
+// With OpaqueAny, google.protobuf.Any whose type can not be resolved is marshaled with the binary value,
+// e.g. {"@type":"type.googleapis.com/pkg.Event","@value":"CgNmb28="},
+// and it is unmarshaled back as is, so that the messages of unknown types are passed through.
+// The value is checked by unmarshaling it into the message of the type if it can be resolved,
+// e.g. the type is registered after the output is made, and it is kept as is only if the type is unknown.
+
+func encodeOpaqueAny(typeUrl string, value []byte, stream *jsoniter.Stream) {
+	stream.WriteObjectStart()
+	stream.WriteObjectField("@type")
+	stream.WriteVal(typeUrl)
+	stream.WriteMore()
+	stream.WriteObjectField("@value")
+	stream.WriteString(base64.StdEncoding.EncodeToString(value))
+	stream.WriteObjectEnd()
+}
+
+func decodeOpaqueAny(e *ProtoExtension, m *anypb.Any, typeUrl string, data []byte, offset int, iter *jsoniter.Iterator) {
+	subIter := iter.API().BorrowIterator(data)
+	subIter.Attachment = iter.Attachment
+	defer iter.API().ReturnIterator(subIter)
+	var value []byte
+	subIter.ReadVal(&value)
+	if perr := transferIterError(iter, subIter, offsetFrom(offset)); perr != nil {
+		perr.prependField("@value", nil)
+		return
+	}
+
+	resolver := e.anyResolver(iter.Attachment, typeUrl)
+	emt, err := resolver.FindMessageByURL(typeUrl)
+	if err != nil && !errors.Is(err, protoregistry.NotFound) {
+		reportIterError(iter, offset, fmt.Errorf("%s: unable to resolve %q: %v", Any_message_fullname, typeUrl, err))
+		return
+	}
+	if err == nil {
+		err = proto.UnmarshalOptions{
+			AllowPartial: true, // the same as marshaling the value
+			Resolver:     resolver,
+		}.Unmarshal(value, emt.New().Interface())
+		if err != nil {
+			reportIterError(iter, offset, fmt.Errorf("%s: unable to unmarshal %q: %v", Any_message_fullname, typeUrl, err))
+			if perr := iterError(iter); perr != nil {
+				perr.prependField("@value", nil)
+			}
+			return
+		}
+	}
+	m.TypeUrl = typeUrl
+	m.Value = value
+}
+
 var wktAnyCodec = &ProtoCodec{
 	EncoderCreator: func(e *ProtoExtension, typ reflect2.Type) jsoniter.ValEncoder {
 		return WrapElemEncoder(typ, &wktAnyEncoder{ext: e}, nil)
@@ -22,15 +22,16 @@ var wktDurationCodec = (&ProtoCodec{}).
 	SetElemEncodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, stream *jsoniter.Stream) {
 		s, err := marshalWktDuration(((*durationpb.Duration)(ptr)))
 		if err != nil {
-			stream.Error = err
+			reportStreamError(stream, err)
 			return
 		}
 		stream.WriteVal(s)
 	}).
 	SetElemDecodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		start := iterOffset(iter)
 		s := iter.ReadString()
 		if err := unmarshalWktDuration(s, (*durationpb.Duration)(ptr)); err != nil {
-			iter.ReportError("protobuf", err.Error())
+			reportIterError(iter, start, err)
 			return
 		}
 	})
@@ -20,13 +20,22 @@ var wktFieldmaskCodec = (&ProtoCodec{}).
 		paths := make([]string, 0, len(v.GetPaths()))
 		for _, s := range v.GetPaths() {
 			if !protoreflect.FullName(s).IsValid() {
-				stream.Error = fmt.Errorf("%s contains invalid path: %q", FieldMask_Paths_field_fullname, s)
+				reportStreamError(stream, fmt.Errorf("%s contains invalid path: %q", FieldMask_Paths_field_fullname, s))
 				return
 			}
+			if e.FieldNamer != nil {
+				cc, ok := e.fieldMaskPathName(s)
+				if !ok {
+					reportStreamError(stream, fmt.Errorf("%s contains irreversible value %q", FieldMask_Paths_field_fullname, s))
+					return
+				}
+				paths = append(paths, cc)
+				continue
+			}
 			// Return error if conversion to camelCase is not reversible.
 			cc := JSONCamelCase(s)
 			if s != JSONSnakeCase(cc) {
-				stream.Error = fmt.Errorf("%s contains irreversible value %q", FieldMask_Paths_field_fullname, s)
+				reportStreamError(stream, fmt.Errorf("%s contains irreversible value %q", FieldMask_Paths_field_fullname, s))
 				return
 			}
 			paths = append(paths, cc)
@@ -34,6 +43,7 @@ var wktFieldmaskCodec = (&ProtoCodec{}).
 		stream.WriteVal(strings.Join(paths, ","))
 	}).
 	SetElemDecodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		start := iterOffset(iter)
 		var str string
 		iter.ReadVal(&str)
 		if str == "" {
@@ -42,9 +52,18 @@ var wktFieldmaskCodec = (&ProtoCodec{}).
 		}
 		paths := strings.Split(str, ",")
 		for idx, s0 := range paths {
+			if e.FieldNamer != nil {
+				s, ok := e.fieldMaskPath(s0)
+				if !ok {
+					reportIterError(iter, start, fmt.Errorf("%v contains invalid path: %q", FieldMask_Paths_field_fullname, s0))
+					return
+				}
+				paths[idx] = s
+				continue
+			}
 			s := JSONSnakeCase(s0)
 			if strings.Contains(s0, "_") || !protoreflect.FullName(s).IsValid() {
-				iter.ReportError("protobuf", fmt.Sprintf("%v contains invalid path: %q", FieldMask_Paths_field_fullname, s0))
+				reportIterError(iter, start, fmt.Errorf("%v contains invalid path: %q", FieldMask_Paths_field_fullname, s0))
 				return
 			}
 			paths[idx] = s
@@ -52,6 +71,89 @@ var wktFieldmaskCodec = (&ProtoCodec{}).
 		(*fieldmaskpb.FieldMask)(ptr).Paths = paths
 	})
 
+// The paths of google.protobuf.FieldMask are converted by FieldNamer segment by segment,
+// but the message of the fields is unknown, so FieldNamer gets fieldMaskPathSegment,
+// whose names are of the segment, and the others are of google.protobuf.FieldMask.paths.
+// The input segment is converted back by finding the proto name, which is named to it by FieldNamer.
+
+var fieldMaskPathsDescriptor = (&fieldmaskpb.FieldMask{}).ProtoReflect().Descriptor().Fields().ByName("paths")
+
+type fieldMaskPathSegment struct {
+	protoreflect.FieldDescriptor
+	name protoreflect.Name
+}
+
+func (s fieldMaskPathSegment) Name() protoreflect.Name         { return s.name }
+func (s fieldMaskPathSegment) FullName() protoreflect.FullName { return protoreflect.FullName(s.name) }
+func (s fieldMaskPathSegment) TextName() string                { return string(s.name) }
+func (s fieldMaskPathSegment) JSONName() string                { return JSONCamelCase(string(s.name)) }
+
+// fieldMaskPathName returns the name of the path s by FieldNamer, false if it can not be converted back
+func (e *ProtoExtension) fieldMaskPathName(s string) (string, bool) {
+	segments := strings.Split(s, ".")
+	for i, segment := range segments {
+		name := e.fieldMaskSegmentName(segment)
+		if back, ok := e.fieldMaskSegment(name); !ok || back != segment {
+			return "", false
+		}
+		segments[i] = name
+	}
+	return strings.Join(segments, "."), true
+}
+
+// fieldMaskPath returns the path whose name by FieldNamer is s0, false if there is none,
+// and the segments in camelCase are also accepted, which is the same as the json names of the fields
+func (e *ProtoExtension) fieldMaskPath(s0 string) (string, bool) {
+	segments := strings.Split(s0, ".")
+	for i, name := range segments {
+		segment, ok := e.fieldMaskSegment(name)
+		if !ok {
+			segment = JSONSnakeCase(name)
+			if strings.Contains(name, "_") || !protoreflect.Name(segment).IsValid() {
+				return "", false
+			}
+		}
+		segments[i] = segment
+	}
+	return strings.Join(segments, "."), true
+}
+
+func (e *ProtoExtension) fieldMaskSegmentName(segment string) string {
+	if name := e.FieldNamer(fieldMaskPathSegment{fieldMaskPathsDescriptor, protoreflect.Name(segment)}); name != "" {
+		return name
+	}
+	return JSONCamelCase(segment)
+}
+
+// fieldMaskSegment finds the segment named to name, e.g. "foo_bar" for "fooBar", "FooBar" or "foo-bar"
+func (e *ProtoExtension) fieldMaskSegment(name string) (string, bool) {
+	for _, segment := range []string{snakeCase(name), JSONSnakeCase(name), name} {
+		if protoreflect.Name(segment).IsValid() && e.fieldMaskSegmentName(segment) == name {
+			return segment, true
+		}
+	}
+	return "", false
+}
+
+// snakeCase converts an identifier of PascalCase, camelCase or kebab-case to snake_case, the leading underscores are trimmed
+func snakeCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '-':
+			c = '_'
+		case isASCIIUpper(c):
+			if len(b) > 0 && b[len(b)-1] != '_' {
+				b = append(b, '_')
+			}
+			c += 'a' - 'A'
+		}
+		b = append(b, c)
+	}
+	return strings.TrimLeft(string(b), "_")
+}
+
 func isASCIILower(c byte) bool {
 	return 'a' <= c && c <= 'z'
 }
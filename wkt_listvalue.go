@@ -1,7 +1,6 @@
 package jsoniterpb
 
 import (
-	"io"
 	"unsafe"
 
 	jsoniter "github.com/json-iterator/go"
@@ -24,7 +23,9 @@ var wktListValueCodec = (&ProtoCodec{}).
 		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
 			v := &structpb.Value{}
 			iter.ReadVal(v)
-			if iter.Error != nil && iter.Error != io.EOF {
+			if e := iterError(iter); e != nil {
+				e.prependIndex(len(values))
+				setIterError(iter, e)
 				return false
 			}
 			values = append(values, v)
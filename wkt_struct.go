@@ -2,7 +2,6 @@ package jsoniterpb
 
 import (
 	"fmt"
-	"io"
 	"unsafe"
 
 	jsoniter "github.com/json-iterator/go"
@@ -23,13 +22,16 @@ var wktStructCodec = (&ProtoCodec{}).
 
 		fields := map[string]*structpb.Value{}
 		iter.ReadMapCB(func(iter *jsoniter.Iterator, field string) bool {
+			start := iterOffset(iter)
 			v := &structpb.Value{}
 			iter.ReadVal(v)
-			if iter.Error != nil && iter.Error != io.EOF {
+			if e := iterError(iter); e != nil {
+				e.prependKey(field)
+				setIterError(iter, e)
 				return false
 			}
 			if _, ok := fields[field]; ok {
-				iter.ReportError("protobuf", fmt.Sprintf(`duplicate %q field`, field))
+				reportIterError(iter, start, fmt.Errorf(`duplicate %q field`, field))
 				return false
 			}
 			fields[field] = v
@@ -21,15 +21,16 @@ var wktTimestampCodec = (&ProtoCodec{}).
 	SetElemEncodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, stream *jsoniter.Stream) {
 		s, err := marshalWktTimestamp(((*timestamppb.Timestamp)(ptr)))
 		if err != nil {
-			stream.Error = err
+			reportStreamError(stream, err)
 			return
 		}
 		stream.WriteVal(s)
 	}).
 	SetElemDecodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		start := iterOffset(iter)
 		s := iter.ReadString()
 		if err := unmarshalWktTimestamp(s, (*timestamppb.Timestamp)(ptr)); err != nil {
-			iter.ReportError("protobuf", err.Error())
+			reportIterError(iter, start, err)
 			return
 		}
 	})
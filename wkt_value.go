@@ -24,14 +24,15 @@ var wktValueCodec = (&ProtoCodec{}).
 		x := ((*structpb.Value)(ptr))
 		err := marshalWktValue(x, stream)
 		if err != nil {
-			stream.Error = fmt.Errorf("%s: %w", Value_message_fullname, err)
+			reportStreamError(stream, fmt.Errorf("%s: %w", Value_message_fullname, err))
 			return
 		}
 	}).
 	SetElemDecodeFunc(func(e *ProtoExtension, ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+		start := iterOffset(iter)
 		err := unmarshalWktValue(((*structpb.Value)(ptr)), iter)
 		if err != nil {
-			iter.ReportError("protobuf", fmt.Sprintf("%s: %v", Value_message_fullname, err))
+			reportIterError(iter, start, fmt.Errorf("%s: %w", Value_message_fullname, err))
 		}
 	})
 